@@ -0,0 +1,17 @@
+package tmpls
+
+import "context"
+
+type localeKey struct{}
+
+// ContextWithLocale returns a copy of ctx carrying the locale (BCP 47 tag, such as "en" or "pt-BR"),
+// which can be used by functions configured by [WithContextFuncs].
+func ContextWithLocale(ctx context.Context, locale string) context.Context {
+	return context.WithValue(ctx, localeKey{}, locale)
+}
+
+// LocaleFromContext returns the locale set by [ContextWithLocale], or an empty string if not set.
+func LocaleFromContext(ctx context.Context) string {
+	locale, _ := ctx.Value(localeKey{}).(string)
+	return locale
+}
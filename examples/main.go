@@ -44,6 +44,8 @@ func main() {
 		tmpls.WithExtensions(".gohtml"),
 		// Rename all templates inside _partials/ from _partials.(name) to _(name).
 		tmpls.WithPrefixMap("_partials/", "_"),
+		// Add built-in page partials styled for Bulma, and page helpers, such as pageInfo and pageSizes.
+		tmpls.WithPagePartials(tmpls.PageStyleBulma),
 		// On execute callback example: always set the content type to text/html.
 		tmpls.WithOnExecute(func(tmpl tmpls.Template, w io.Writer, _ any) error {
 			fmt.Printf("Executing [%s]%s\n", tmpl.Name(), tmpl.Unwrap().(*html.Template).DefinedTemplates())
@@ -71,10 +73,11 @@ func main() {
 	http.Handle("GET /static/", http.StripPrefix("/static/", static))
 	http.HandleFunc("GET /", func(res http.ResponseWriter, req *http.Request) {
 		// Paging demonstration, just empty data.
+		paging := page.NewPaging(req.URL)
 		p := page.NewPage[any](
-			page.NewPaging(req.URL),              // Paginator
-			make([]any, page.DefaultPageSize*10), // Data
-			page.DefaultPageSize*10,              // Count
+			paging,                         // Paginator
+			make([]any, paging.PageSize()), // Data
+			page.DefaultPageSize*10,        // Count
 		)
 
 		// Execute template with data.
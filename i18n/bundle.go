@@ -0,0 +1,361 @@
+// Package i18n provides message catalogs and template functions for translating templates.
+package i18n
+
+import (
+	"encoding/json"
+	"fmt"
+	"github.com/mawngo/go-tmpls/v2"
+	"io/fs"
+	fspath "path"
+	"sort"
+	"strings"
+)
+
+// Bundle is a set of message catalogs by locale.
+//
+// Catalogs are JSON files, named by locale (en.json, pt-BR.json) or placed in a directory named by locale
+// (en/common.json). Values are either a string or an object of plural categories:
+//
+//	{
+//	  "hello": "Hello {name}",
+//	  "items": {"one": "{count} item", "other": "{count} items"},
+//	  "nav": {"home": "Home"}
+//	}
+//
+// Nested objects that are not plural forms are flattened using dot, for example "nav.home".
+type Bundle struct {
+	fallback string
+	// Map of normalized locale to locale name.
+	locales map[string]string
+	// Map of normalized locale to messages by key.
+	catalogs map[string]map[string]message
+	rules    map[string]PluralRule
+}
+
+type message struct {
+	text   string
+	plural map[PluralCategory]string
+}
+
+// BundleOption is the option for configuring [Bundle].
+type BundleOption func(*Bundle)
+
+// WithPluralRule set the plural rule of the language (base language, such as "en"),
+// replacing the built-in one.
+func WithPluralRule(lang string, rule PluralRule) BundleOption {
+	return func(b *Bundle) {
+		b.rules[normalize(lang)] = rule
+	}
+}
+
+// Load create a [Bundle] from the JSON catalogs in the file system.
+// The fallback locale is used when a message is not found in the requested locale and its base language.
+func Load(fsys fs.FS, fallback string, options ...BundleOption) (*Bundle, error) {
+	b := &Bundle{
+		fallback: fallback,
+		locales:  make(map[string]string),
+		catalogs: make(map[string]map[string]message),
+		rules:    make(map[string]PluralRule, len(pluralRules)),
+	}
+	for lang, rule := range pluralRules {
+		b.rules[lang] = rule
+	}
+	for _, option := range options {
+		option(b)
+	}
+
+	err := fs.WalkDir(fsys, ".", func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if d.IsDir() || fspath.Ext(path) != ".json" {
+			return nil
+		}
+		locale, _, nested := strings.Cut(path, "/")
+		if !nested {
+			locale = strings.TrimSuffix(path, ".json")
+		}
+
+		data, err := fs.ReadFile(fsys, path)
+		if err != nil {
+			return err
+		}
+		var raw map[string]any
+		if err := json.Unmarshal(data, &raw); err != nil {
+			return fmt.Errorf("catalog %s: %w", path, err)
+		}
+
+		key := normalize(locale)
+		catalog, ok := b.catalogs[key]
+		if !ok {
+			catalog = make(map[string]message)
+			b.catalogs[key] = catalog
+			b.locales[key] = locale
+		}
+		return flatten(catalog, "", raw, path)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return b, nil
+}
+
+// flatten add the raw messages to the catalog.
+func flatten(catalog map[string]message, prefix string, raw map[string]any, path string) error {
+	for k, v := range raw {
+		key := prefix + k
+		switch v := v.(type) {
+		case string:
+			if _, ok := catalog[key]; ok {
+				return fmt.Errorf("catalog %s: duplicated message %q", path, key)
+			}
+			catalog[key] = message{text: v}
+		case map[string]any:
+			if !isPluralForms(v) {
+				if err := flatten(catalog, key+".", v, path); err != nil {
+					return err
+				}
+				continue
+			}
+			if _, ok := catalog[key]; ok {
+				return fmt.Errorf("catalog %s: duplicated message %q", path, key)
+			}
+			msg := message{plural: make(map[PluralCategory]string, len(v))}
+			for category, text := range v {
+				msg.plural[PluralCategory(category)] = text.(string)
+			}
+			msg.text = msg.plural[PluralOther]
+			catalog[key] = msg
+		default:
+			return fmt.Errorf("catalog %s: invalid message %q", path, key)
+		}
+	}
+	return nil
+}
+
+// isPluralForms return whether the object is a plural message, which only contains plural category string.
+func isPluralForms(v map[string]any) bool {
+	if len(v) == 0 {
+		return false
+	}
+	for k, text := range v {
+		if _, ok := text.(string); !ok || !isPluralCategory(k) {
+			return false
+		}
+	}
+	return true
+}
+
+// Fallback returns the fallback locale.
+func (b *Bundle) Fallback() string {
+	return b.fallback
+}
+
+// Locales returns the loaded locales, sorted.
+func (b *Bundle) Locales() []string {
+	locales := make([]string, 0, len(b.locales))
+	for _, locale := range b.locales {
+		locales = append(locales, locale)
+	}
+	sort.Strings(locales)
+	return locales
+}
+
+// Chain returns the fallback chain of the locale, which are the locale, its base language,
+// the fallback locale and its base language. For example: pt-BR, pt, en.
+func (b *Bundle) Chain(locale string) []string {
+	chain := make([]string, 0, 4)
+	seen := make(map[string]struct{}, 4)
+	for _, l := range []string{locale, baseLanguage(locale), b.fallback, baseLanguage(b.fallback)} {
+		l = normalize(l)
+		if l == "" {
+			continue
+		}
+		if _, ok := seen[l]; ok {
+			continue
+		}
+		seen[l] = struct{}{}
+		chain = append(chain, l)
+	}
+	return chain
+}
+
+// Resolve returns the loaded locale used for the locale, which is the first locale of its [Bundle.Chain]
+// that has a catalog, or an empty string if it is the one used by the fallback locale.
+func (b *Bundle) Resolve(locale string) string {
+	resolved := b.resolve(locale)
+	if resolved == "" || resolved == b.resolve(b.fallback) {
+		return ""
+	}
+	return b.locales[resolved]
+}
+
+func (b *Bundle) resolve(locale string) string {
+	for _, l := range b.Chain(locale) {
+		if _, ok := b.catalogs[l]; ok {
+			return l
+		}
+	}
+	return ""
+}
+
+// Translate returns the message of the key in the locale, interpolated with the args.
+// Returns the key if the message is not found in the fallback chain.
+//
+// Args are either a map[string]any, or key-value pairs, replacing {key} placeholders in the message.
+func (b *Bundle) Translate(locale string, key string, args ...any) string {
+	msg, ok := b.lookup(locale, key)
+	if !ok {
+		return key
+	}
+	return interpolate(msg.text, newArgs(args))
+}
+
+// TranslatePlural returns the plural form of the message for the count in the locale, interpolated with the args.
+// The count is also available as the {count} placeholder.
+// If the count is a slice or map, its length is used.
+func (b *Bundle) TranslatePlural(locale string, key string, count any, args ...any) string {
+	values := newArgs(args)
+	values["count"] = count
+	n, isNumber := toCount(count)
+	if isNumber && !isNumeric(count) {
+		values["count"] = n
+	}
+
+	for _, l := range b.Chain(locale) {
+		msg, ok := b.catalogs[l][key]
+		if !ok {
+			continue
+		}
+		text := msg.text
+		if msg.plural != nil && isNumber {
+			if form, ok := msg.plural[b.pluralRule(l)(n)]; ok {
+				text = form
+			}
+		}
+		return interpolate(text, values)
+	}
+	return key
+}
+
+// PluralCategory returns the plural category of the count in the locale.
+func (b *Bundle) PluralCategory(locale string, n float64) PluralCategory {
+	return b.pluralRule(locale)(n)
+}
+
+func (b *Bundle) pluralRule(locale string) PluralRule {
+	if rule, ok := b.rules[normalize(locale)]; ok {
+		return rule
+	}
+	if rule, ok := b.rules[baseLanguage(locale)]; ok {
+		return rule
+	}
+	return PluralRuleOne
+}
+
+func (b *Bundle) lookup(locale string, key string) (message, bool) {
+	for _, l := range b.Chain(locale) {
+		if msg, ok := b.catalogs[l][key]; ok {
+			return msg, true
+		}
+	}
+	return message{}, false
+}
+
+// Funcs returns the template functions bound to the locale.
+//
+//   - t: translate the key, see [Bundle.Translate].
+//   - tn: translate the plural form of the key, see [Bundle.TranslatePlural].
+//   - locale: returns the locale.
+//
+// If the locale is empty, the fallback locale is used.
+func (b *Bundle) Funcs(locale string) tmpls.FuncMap {
+	if locale == "" {
+		locale = b.fallback
+	}
+	return tmpls.FuncMap{
+		"t": func(key string, args ...any) string {
+			return b.Translate(locale, key, args...)
+		},
+		"tn": func(key string, count any, args ...any) string {
+			return b.TranslatePlural(locale, key, count, args...)
+		},
+		"locale": func() string {
+			return locale
+		},
+	}
+}
+
+// Option returns the [tmpls.TemplatesOption] that binds [Bundle.Funcs] to the locale of each execution,
+// which is set using [tmpls.ContextWithLocale] (or [Bundle.Middleware])
+// and passed to [tmpls.Templates.ExecuteTemplateContext].
+// The locale is resolved using [Bundle.Resolve], so templates are only cloned once per loaded locale.
+func (b *Bundle) Option() tmpls.TemplatesOption {
+	return tmpls.WithLocaleFuncs(b.Resolve, b.Funcs)
+}
+
+// newArgs create the interpolation values from a map or key-value pairs.
+func newArgs(args []any) map[string]any {
+	if len(args) == 1 {
+		if m, ok := args[0].(map[string]any); ok {
+			values := make(map[string]any, len(m)+1)
+			for k, v := range m {
+				values[k] = v
+			}
+			return values
+		}
+	}
+	values := make(map[string]any, len(args)/2+1)
+	for i := 0; i+1 < len(args); i += 2 {
+		values[fmt.Sprint(args[i])] = args[i+1]
+	}
+	return values
+}
+
+// interpolate replace {key} placeholders in the text. Unknown placeholders are kept as is.
+func interpolate(text string, values map[string]any) string {
+	if len(values) == 0 || !strings.Contains(text, "{") {
+		return text
+	}
+	var sb strings.Builder
+	for {
+		start := strings.IndexByte(text, '{')
+		if start < 0 {
+			break
+		}
+		end := strings.IndexByte(text[start:], '}')
+		if end < 0 {
+			break
+		}
+		end += start
+		sb.WriteString(text[:start])
+		if v, ok := values[text[start+1:end]]; ok {
+			sb.WriteString(fmt.Sprint(v))
+		} else {
+			sb.WriteString(text[start : end+1])
+		}
+		text = text[end+1:]
+	}
+	sb.WriteString(text)
+	return sb.String()
+}
+
+// isNumeric return whether the count is printed as is.
+func isNumeric(v any) bool {
+	switch v.(type) {
+	case int, int8, int16, int32, int64, uint, uint8, uint16, uint32, uint64, float32, float64, string:
+		return true
+	}
+	return false
+}
+
+// normalize returns the lower-case locale, using dash as separator.
+func normalize(locale string) string {
+	return strings.ToLower(strings.ReplaceAll(strings.TrimSpace(locale), "_", "-"))
+}
+
+// baseLanguage returns the normalized base language of the locale, for example "pt" of "pt-BR".
+func baseLanguage(locale string) string {
+	base, _, _ := strings.Cut(normalize(locale), "-")
+	return base
+}
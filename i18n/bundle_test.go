@@ -0,0 +1,157 @@
+package i18n
+
+import (
+	"context"
+	"github.com/mawngo/go-tmpls/v2"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"testing/fstest"
+)
+
+func newTestBundle(t *testing.T) *Bundle {
+	t.Helper()
+	fsys := fstest.MapFS{
+		"en.json": {Data: []byte(`{
+			"hello": "Hello {name}",
+			"items": {"one": "{count} item", "other": "{count} items"},
+			"nav": {"home": "Home", "about": "About"}
+		}`)},
+		"pt/common.json": {Data: []byte(`{"hello": "Olá {name}"}`)},
+		"pt-BR.json":     {Data: []byte(`{"nav": {"home": "Início"}}`)},
+		"ru.json":        {Data: []byte(`{"items": {"one": "{count} файл", "few": "{count} файла", "many": "{count} файлов"}}`)},
+	}
+	b, err := Load(fsys, "en")
+	if err != nil {
+		t.Fatalf("load: %v", err)
+	}
+	return b
+}
+
+func TestBundleTranslate(t *testing.T) {
+	b := newTestBundle(t)
+	tests := []struct {
+		locale string
+		key    string
+		args   []any
+		want   string
+	}{
+		{"en", "hello", []any{"name", "Bob"}, "Hello Bob"},
+		{"pt-BR", "hello", []any{map[string]any{"name": "Ana"}}, "Olá Ana"},
+		{"pt_br", "nav.home", nil, "Início"},
+		{"pt-BR", "nav.about", nil, "About"},
+		{"de", "nav.home", nil, "Home"},
+		{"en", "missing", nil, "missing"},
+		{"en", "hello", nil, "Hello {name}"},
+	}
+	for _, tt := range tests {
+		if got := b.Translate(tt.locale, tt.key, tt.args...); got != tt.want {
+			t.Fatalf("Translate(%q, %q) = %q, want %q", tt.locale, tt.key, got, tt.want)
+		}
+	}
+}
+
+func TestBundleTranslatePlural(t *testing.T) {
+	b := newTestBundle(t)
+	tests := []struct {
+		locale string
+		count  any
+		want   string
+	}{
+		{"en", 1, "1 item"},
+		{"en", 0, "0 items"},
+		{"en", []int{1, 2}, "2 items"},
+		{"ru", 1, "1 файл"},
+		{"ru", 3, "3 файла"},
+		{"ru", 11, "11 файлов"},
+		{"ru", 22, "22 файла"},
+		{"pt", 1, "1 item"},
+	}
+	for _, tt := range tests {
+		if got := b.TranslatePlural(tt.locale, "items", tt.count); got != tt.want {
+			t.Fatalf("TranslatePlural(%q, %v) = %q, want %q", tt.locale, tt.count, got, tt.want)
+		}
+	}
+}
+
+func TestBundleNegotiate(t *testing.T) {
+	b := newTestBundle(t)
+	tests := []struct {
+		header string
+		want   string
+	}{
+		{"", "en"},
+		{"pt-BR,pt;q=0.9", "pt-BR"},
+		{"pt-PT", "pt"},
+		{"fr;q=0.9, ru;q=0.5", "ru"},
+		{"ru;q=0.5, pt-BR;q=0.8", "pt-BR"},
+		{"de, *;q=0.1", "en"},
+		{"ru;q=0", "en"},
+	}
+	for _, tt := range tests {
+		if got := b.Negotiate(tt.header); got != tt.want {
+			t.Fatalf("Negotiate(%q) = %q, want %q", tt.header, got, tt.want)
+		}
+	}
+}
+
+func TestBundleTemplates(t *testing.T) {
+	b := newTestBundle(t)
+	fsys := fstest.MapFS{
+		"index.gohtml": {Data: []byte(`{{ t "hello" "name" .Name }}, {{ tn "items" .Count }} ({{ locale }})`)},
+	}
+	templates, err := tmpls.New(fsys, b.Option())
+	if err != nil {
+		t.Fatalf("new templates: %v", err)
+	}
+
+	handler := b.Middleware(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		templates.MustExecuteTemplateContext(r.Context(), w, "index", map[string]any{"Name": "Ana", "Count": 2})
+	}))
+	for _, locale := range []string{"pt-BR", "en"} {
+		req := httptest.NewRequest(http.MethodGet, "/", nil)
+		req.Header.Set("Accept-Language", locale)
+		res := httptest.NewRecorder()
+		handler.ServeHTTP(res, req)
+		want := map[string]string{
+			"pt-BR": "Olá Ana, 2 items (pt-BR)",
+			"en":    "Hello Ana, 2 items (en)",
+		}[locale]
+		if got := res.Body.String(); got != want {
+			t.Fatalf("render %s = %q, want %q", locale, got, want)
+		}
+		if res.Header().Get("Content-Language") != locale {
+			t.Fatalf("content language = %q", res.Header().Get("Content-Language"))
+		}
+	}
+
+	var sb strings.Builder
+	if err := templates.ExecuteTemplate(&sb, "index", map[string]any{"Name": "Bob", "Count": 1}); err != nil {
+		t.Fatalf("execute: %v", err)
+	}
+	if sb.String() != "Hello Bob, 1 item (en)" {
+		t.Fatalf("render default = %q", sb.String())
+	}
+	sb.Reset()
+	ctx := tmpls.ContextWithLocale(context.Background(), "ru")
+	if err := templates.ExecuteTemplateContext(ctx, &sb, "index", map[string]any{"Name": "Bob", "Count": 5}); err != nil {
+		t.Fatalf("execute: %v", err)
+	}
+	if sb.String() != "Hello Bob, 5 файлов (ru)" {
+		t.Fatalf("render ru = %q", sb.String())
+	}
+}
+
+func TestBundleResolve(t *testing.T) {
+	b := newTestBundle(t)
+	tests := map[string]string{
+		"pt-BR": "pt-BR", "pt_br": "pt-BR", "pt-PT": "pt", "pt": "pt", "ru-RU": "ru",
+		"en": "", "en-GB": "", "de": "", "": "",
+	}
+	for locale, want := range tests {
+		if got := b.Resolve(locale); got != want {
+			t.Fatalf("resolve %q = %q, want %q", locale, got, want)
+		}
+	}
+}
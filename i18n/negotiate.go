@@ -0,0 +1,93 @@
+package i18n
+
+import (
+	"github.com/mawngo/go-tmpls/v2"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// Negotiate returns the loaded locale that best matches the Accept-Language header value,
+// or the fallback locale if none matches.
+//
+// Each language range is matched exactly, then by its base language, then by any loaded locale
+// of the same base language. For example, "pt" matches "pt-BR" if "pt" is not loaded.
+func (b *Bundle) Negotiate(acceptLanguage string) string {
+	for _, tag := range parseAcceptLanguage(acceptLanguage) {
+		if tag == "*" {
+			break
+		}
+		if locale, ok := b.locales[tag]; ok {
+			return locale
+		}
+		base := baseLanguage(tag)
+		if locale, ok := b.locales[base]; ok {
+			return locale
+		}
+		for _, locale := range b.Locales() {
+			if baseLanguage(locale) == base {
+				return locale
+			}
+		}
+	}
+	return b.fallback
+}
+
+// Middleware set the locale of the request context (see [tmpls.ContextWithLocale]) by negotiating
+// the Accept-Language header, unless a locale is already set by a previous middleware.
+// The Content-Language header of the response is set to the locale.
+func (b *Bundle) Middleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		locale := tmpls.LocaleFromContext(r.Context())
+		if locale == "" {
+			locale = b.Negotiate(r.Header.Get("Accept-Language"))
+			r = r.WithContext(tmpls.ContextWithLocale(r.Context(), locale))
+		}
+		w.Header().Add("Vary", "Accept-Language")
+		w.Header().Set("Content-Language", locale)
+		next.ServeHTTP(w, r)
+	})
+}
+
+// parseAcceptLanguage returns the normalized language ranges of the header, ordered by quality.
+// Ranges with zero quality are excluded.
+func parseAcceptLanguage(header string) []string {
+	type weighted struct {
+		tag string
+		q   float64
+	}
+	ranges := make([]weighted, 0, 5)
+	for _, part := range strings.Split(header, ",") {
+		tag, params, _ := strings.Cut(part, ";")
+		tag = normalize(tag)
+		if tag == "" {
+			continue
+		}
+		q := 1.0
+		for _, param := range strings.Split(params, ";") {
+			name, value, ok := strings.Cut(strings.TrimSpace(param), "=")
+			if !ok || name != "q" {
+				continue
+			}
+			parsed, err := strconv.ParseFloat(value, 64)
+			if err != nil {
+				parsed = 0
+			}
+			q = parsed
+		}
+		if q <= 0 {
+			continue
+		}
+		ranges = append(ranges, weighted{tag: tag, q: q})
+	}
+	sort.SliceStable(ranges, func(i, j int) bool {
+		return ranges[i].q > ranges[j].q
+	})
+
+	tags := make([]string, 0, len(ranges))
+	for _, r := range ranges {
+		tags = append(tags, r.tag)
+	}
+	return tags
+}
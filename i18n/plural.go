@@ -0,0 +1,162 @@
+package i18n
+
+import (
+	"math"
+	"reflect"
+	"strconv"
+)
+
+// PluralCategory is the CLDR plural category of a count.
+type PluralCategory string
+
+const (
+	PluralZero  PluralCategory = "zero"
+	PluralOne   PluralCategory = "one"
+	PluralTwo   PluralCategory = "two"
+	PluralFew   PluralCategory = "few"
+	PluralMany  PluralCategory = "many"
+	PluralOther PluralCategory = "other"
+)
+
+// isPluralCategory return whether the string is a valid [PluralCategory].
+func isPluralCategory(s string) bool {
+	switch PluralCategory(s) {
+	case PluralZero, PluralOne, PluralTwo, PluralFew, PluralMany, PluralOther:
+		return true
+	}
+	return false
+}
+
+// PluralRule returns the plural category of the count.
+type PluralRule func(n float64) PluralCategory
+
+// pluralRules is the built-in plural rules by base language.
+// Languages that are not listed use [PluralRuleOne].
+var pluralRules = map[string]PluralRule{
+	"ja": PluralRuleOther,
+	"zh": PluralRuleOther,
+	"ko": PluralRuleOther,
+	"vi": PluralRuleOther,
+	"th": PluralRuleOther,
+	"id": PluralRuleOther,
+	"ms": PluralRuleOther,
+	"fr": pluralRuleZeroOne,
+	"pt": pluralRuleZeroOne,
+	"ru": pluralRuleSlavic,
+	"uk": pluralRuleSlavic,
+	"be": pluralRuleSlavic,
+	"pl": pluralRulePolish,
+	"cs": pluralRuleCzech,
+	"sk": pluralRuleCzech,
+	"ar": pluralRuleArabic,
+}
+
+// PluralRuleOther is the plural rule of languages without plural forms, such as Japanese and Chinese.
+func PluralRuleOther(float64) PluralCategory {
+	return PluralOther
+}
+
+// PluralRuleOne is the plural rule of languages such as English and German: one for 1, other for the rest.
+func PluralRuleOne(n float64) PluralCategory {
+	if n == 1 {
+		return PluralOne
+	}
+	return PluralOther
+}
+
+// pluralRuleZeroOne is the plural rule of French and Portuguese: one for 0 and 1.
+func pluralRuleZeroOne(n float64) PluralCategory {
+	if n >= 0 && n < 2 && n == math.Trunc(n) {
+		return PluralOne
+	}
+	return PluralOther
+}
+
+func pluralRuleSlavic(n float64) PluralCategory {
+	if n != math.Trunc(n) {
+		return PluralOther
+	}
+	i := int64(math.Abs(n))
+	switch {
+	case i%10 == 1 && i%100 != 11:
+		return PluralOne
+	case i%10 >= 2 && i%10 <= 4 && (i%100 < 12 || i%100 > 14):
+		return PluralFew
+	default:
+		return PluralMany
+	}
+}
+
+func pluralRulePolish(n float64) PluralCategory {
+	if n != math.Trunc(n) {
+		return PluralOther
+	}
+	i := int64(math.Abs(n))
+	switch {
+	case i == 1:
+		return PluralOne
+	case i%10 >= 2 && i%10 <= 4 && (i%100 < 12 || i%100 > 14):
+		return PluralFew
+	default:
+		return PluralMany
+	}
+}
+
+func pluralRuleCzech(n float64) PluralCategory {
+	if n != math.Trunc(n) {
+		return PluralMany
+	}
+	switch i := int64(math.Abs(n)); {
+	case i == 1:
+		return PluralOne
+	case i >= 2 && i <= 4:
+		return PluralFew
+	default:
+		return PluralOther
+	}
+}
+
+func pluralRuleArabic(n float64) PluralCategory {
+	if n != math.Trunc(n) {
+		return PluralOther
+	}
+	i := int64(math.Abs(n))
+	switch {
+	case i == 0:
+		return PluralZero
+	case i == 1:
+		return PluralOne
+	case i == 2:
+		return PluralTwo
+	case i%100 >= 3 && i%100 <= 10:
+		return PluralFew
+	case i%100 >= 11:
+		return PluralMany
+	default:
+		return PluralOther
+	}
+}
+
+// toCount converts the count to float64, return false if it is not a number.
+func toCount(v any) (float64, bool) {
+	switch v := v.(type) {
+	case string:
+		f, err := strconv.ParseFloat(v, 64)
+		return f, err == nil
+	case nil:
+		return 0, false
+	}
+	rv := reflect.Indirect(reflect.ValueOf(v))
+	switch rv.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return float64(rv.Int()), true
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return float64(rv.Uint()), true
+	case reflect.Float32, reflect.Float64:
+		return rv.Float(), true
+	case reflect.Slice, reflect.Array, reflect.Map:
+		return float64(rv.Len()), true
+	default:
+		return 0, false
+	}
+}
@@ -1,6 +1,7 @@
 package internal
 
 import (
+	"errors"
 	"fmt"
 	"reflect"
 	"strings"
@@ -14,6 +15,12 @@ func NewBuiltinFuncMap(excludes ...string) map[string]any {
 		"until":   until,
 		"ternary": ternary,
 
+		"default":  defaultValue,
+		"coalesce": coalesce,
+		"empty":    empty,
+		"required": required,
+		"fail":     fail,
+
 		"int": toInt,
 		"add": func(a any, i ...any) int {
 			sum := toInt(a)
@@ -23,8 +30,12 @@ func NewBuiltinFuncMap(excludes ...string) map[string]any {
 			return sum
 		},
 		"sub": func(a, b any) int { return toInt(a) - toInt(b) },
-		"div": func(a, b any) int {
-			return toInt(a) / toInt(b)
+		"div": func(a, b any) (int, error) {
+			d := toInt(b)
+			if d == 0 {
+				return 0, errors.New("div: division by zero")
+			}
+			return toInt(a) / d, nil
 		},
 		"mul": func(a any, i ...any) int {
 			total := toInt(a)
@@ -86,6 +97,25 @@ func NewBuiltinFuncMap(excludes ...string) map[string]any {
 		"lower": strings.ToLower,
 		"title": strings.ToTitle,
 
+		"trim":       trim,
+		"replace":    replace,
+		"split":      split,
+		"join":       join,
+		"contains":   contains,
+		"hasPrefix":  hasPrefix,
+		"hasSuffix":  hasSuffix,
+		"repeat":     repeat,
+		"substr":     substr,
+		"abbrev":     abbrev,
+		"wrap":       wrap,
+		"slugify":    slugify,
+		"camelcase":  camelcase,
+		"snakecase":  snakecase,
+		"kebabcase":  kebabcase,
+		"capitalize": capitalize,
+		"initials":   initials,
+		"nl2br":      nl2br,
+
 		"datefmt":       datefmt,
 		"datetimefmt":   datetimefmt,
 		"dateInZone":    dateInZone,
@@ -94,6 +124,32 @@ func NewBuiltinFuncMap(excludes ...string) map[string]any {
 		"toDate":        toDate,
 		"duration":      duration,
 		"durationRound": durationRound,
+
+		"timeago":  timeago,
+		"bytes":    humanBytes,
+		"ordinal":  ordinal,
+		"plural":   plural,
+		"truncate": truncate,
+
+		"list":    list,
+		"append":  push,
+		"first":   first,
+		"last":    last,
+		"sublist": sublist,
+		"reverse": reverse,
+		"uniq":    uniq,
+		"sortBy":  sortBy,
+		"groupBy": groupBy,
+		"keys":    keys,
+		"values":  values,
+		"pluck":   pluck,
+		"where":   where,
+		"chunk":   chunk,
+		"has":     has,
+		"merge":   merge,
+	}
+	for name, fn := range NewLocaleFuncMap(defaultLocale) {
+		builtin[name] = fn
 	}
 	for _, name := range excludes {
 		delete(builtin, name)
@@ -152,30 +208,50 @@ func dict(v ...any) map[string]any {
 
 // dig traverses a nested set of dicts, selecting keys from a list of values.
 // It returns a default value if any of the keys are not found at the associated dict.
+// The dict can be any map with string keys (such as page.D or map[string]string) or a struct.
+//
+// Example usage: dig "user" "address" "city" "unknown" .Data.
 func dig(ps ...any) (any, error) {
 	if len(ps) < 3 {
-		panic("dig needs at least three arguments")
+		return nil, fmt.Errorf("dig: expected at least three arguments, got %d", len(ps))
 	}
-	dict := ps[len(ps)-1].(map[string]any)
+	dict := ps[len(ps)-1]
 	def := ps[len(ps)-2]
 	ks := make([]string, len(ps)-2)
 	for i := 0; i < len(ks); i++ {
-		ks[i] = ps[i].(string)
+		k, ok := ps[i].(string)
+		if !ok {
+			return nil, fmt.Errorf("dig: key %d must be string, got %T", i, ps[i])
+		}
+		ks[i] = k
 	}
-
 	return digFromDict(dict, def, ks)
 }
 
-func digFromDict(dict map[string]any, d any, ks []string) (any, error) {
-	k, ns := ks[0], ks[1:]
-	step, has := dict[k]
-	if !has {
-		return d, nil
-	}
-	if len(ns) == 0 {
-		return step, nil
+func digFromDict(dict any, d any, ks []string) (any, error) {
+	current := dict
+	for _, k := range ks {
+		rv := reflect.ValueOf(current)
+		for rv.Kind() == reflect.Pointer || rv.Kind() == reflect.Interface {
+			if rv.IsNil() {
+				return d, nil
+			}
+			rv = rv.Elem()
+		}
+		switch {
+		case !rv.IsValid():
+			return d, nil
+		case rv.Kind() == reflect.Map && rv.Type().Key().Kind() == reflect.String, rv.Kind() == reflect.Struct:
+		default:
+			return nil, fmt.Errorf("dig: cannot select key %q from %T", k, current)
+		}
+		step, ok := fieldOfValue(rv, k)
+		if !ok {
+			return d, nil
+		}
+		current = step
 	}
-	return digFromDict(step.(map[string]any), d, ns)
+	return current, nil
 }
 
 // ternary returns the first value if the last value is true, otherwise returns the second value.
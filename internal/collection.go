@@ -0,0 +1,484 @@
+package internal
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+	"time"
+)
+
+// list create a list from the values.
+//
+// Example usage: list 1 2 3.
+func list(v ...any) []any {
+	return v
+}
+
+// push return a new list with the values appended.
+//
+// Example usage: append .Items 4 5.
+func push(l any, v ...any) ([]any, error) {
+	items, err := toList("append", l)
+	if err != nil {
+		return nil, err
+	}
+	res := make([]any, 0, len(items)+len(v))
+	res = append(res, items...)
+	return append(res, v...), nil
+}
+
+// first return the first item of the list, or nil if the list is empty.
+//
+// Example usage: first .Items.
+func first(l any) (any, error) {
+	items, err := toList("first", l)
+	if err != nil || len(items) == 0 {
+		return nil, err
+	}
+	return items[0], nil
+}
+
+// last return the last item of the list, or nil if the list is empty.
+//
+// Example usage: last .Items.
+func last(l any) (any, error) {
+	items, err := toList("last", l)
+	if err != nil || len(items) == 0 {
+		return nil, err
+	}
+	return items[len(items)-1], nil
+}
+
+// sublist return the items from start (inclusive) to end (exclusive) of the list or string.
+// Unlike the standard slice function, strings are sliced by rune and out of range indices are clamped instead of failing.
+//
+// Example usage: sublist .Items 1 3, sublist .Items 2.
+func sublist(l any, indices ...any) (any, error) {
+	if s, ok := l.(string); ok {
+		runes := []rune(s)
+		start, end := sliceRange(len(runes), indices)
+		return string(runes[start:end]), nil
+	}
+	items, err := toList("sublist", l)
+	if err != nil {
+		return nil, err
+	}
+	start, end := sliceRange(len(items), indices)
+	return items[start:end], nil
+}
+
+func sliceRange(n int, indices []any) (int, int) {
+	start, end := 0, n
+	if len(indices) > 0 {
+		start = min(max(toInt(indices[0]), 0), n)
+	}
+	if len(indices) > 1 {
+		end = min(max(toInt(indices[1]), start), n)
+	}
+	return start, end
+}
+
+// reverse return a new list in reverse order.
+//
+// Example usage: reverse .Items.
+func reverse(l any) ([]any, error) {
+	items, err := toList("reverse", l)
+	if err != nil {
+		return nil, err
+	}
+	res := make([]any, len(items))
+	for i, item := range items {
+		res[len(items)-1-i] = item
+	}
+	return res, nil
+}
+
+// uniq return a new list without duplicated items, keeping the first occurrence.
+// Items are compared like [has], so 1 and int64(1) are duplicates.
+//
+// Example usage: uniq .Tags.
+func uniq(l any) ([]any, error) {
+	items, err := toList("uniq", l)
+	if err != nil {
+		return nil, err
+	}
+	res := make([]any, 0, len(items))
+	for _, item := range items {
+		if !containsItem(res, item) {
+			res = append(res, item)
+		}
+	}
+	return res, nil
+}
+
+// sortBy return a new list sorted by the field (struct field, method or map key, can be nested using dot),
+// prefixed by a negative sign '-' for descending order.
+// An empty field sorts by the items themselves.
+//
+// Example usage: sortBy "Name" .Users, sortBy "-CreatedAt" .Posts.
+func sortBy(field string, l any) ([]any, error) {
+	items, err := toList("sortBy", l)
+	if err != nil {
+		return nil, err
+	}
+	desc := strings.HasPrefix(field, "-")
+	field = strings.TrimPrefix(field, "-")
+
+	keys := make([]any, len(items))
+	for i, item := range items {
+		keys[i], _ = fieldOf(item, field)
+	}
+	indexes := make([]int, len(items))
+	for i := range indexes {
+		indexes[i] = i
+	}
+	sort.SliceStable(indexes, func(i, j int) bool {
+		c := compareItems(keys[indexes[i]], keys[indexes[j]])
+		if desc {
+			return c > 0
+		}
+		return c < 0
+	})
+
+	res := make([]any, len(items))
+	for i, index := range indexes {
+		res[i] = items[index]
+	}
+	return res, nil
+}
+
+// groupBy group the items by the string value of the field.
+//
+// Example usage: range $status, $items := groupBy "Status" .Orders.
+func groupBy(field string, l any) (map[string][]any, error) {
+	items, err := toList("groupBy", l)
+	if err != nil {
+		return nil, err
+	}
+	res := make(map[string][]any)
+	for _, item := range items {
+		value, _ := fieldOf(item, field)
+		key := ""
+		if value != nil {
+			key = strval(value)
+		}
+		res[key] = append(res[key], item)
+	}
+	return res, nil
+}
+
+// keys return the sorted keys of the map.
+//
+// Example usage: keys .Settings.
+func keys(m any) ([]any, error) {
+	rv, err := toMap("keys", m)
+	if err != nil || !rv.IsValid() {
+		return []any{}, err
+	}
+	res := make([]any, 0, rv.Len())
+	for _, key := range sortedKeys(rv) {
+		res = append(res, key.Interface())
+	}
+	return res, nil
+}
+
+// values return the values of the map, in the order of sorted keys.
+//
+// Example usage: values .Settings.
+func values(m any) ([]any, error) {
+	rv, err := toMap("values", m)
+	if err != nil || !rv.IsValid() {
+		return []any{}, err
+	}
+	res := make([]any, 0, rv.Len())
+	for _, key := range sortedKeys(rv) {
+		res = append(res, rv.MapIndex(key).Interface())
+	}
+	return res, nil
+}
+
+// pluck return the value of the field of each item. Items without the field are skipped.
+//
+// Example usage: pluck "Name" .Users.
+func pluck(field string, l any) ([]any, error) {
+	items, err := toList("pluck", l)
+	if err != nil {
+		return nil, err
+	}
+	res := make([]any, 0, len(items))
+	for _, item := range items {
+		if value, ok := fieldOf(item, field); ok {
+			res = append(res, value)
+		}
+	}
+	return res, nil
+}
+
+// where return the items whose field equals the value.
+// Numbers are compared by value regardless of their types, and strings are compared with the string form of the field.
+//
+// Example usage: where "Status" "active" .Users.
+func where(field string, value any, l any) ([]any, error) {
+	items, err := toList("where", l)
+	if err != nil {
+		return nil, err
+	}
+	res := make([]any, 0, len(items))
+	for _, item := range items {
+		if v, ok := fieldOf(item, field); ok && equalItems(v, value) {
+			res = append(res, item)
+		}
+	}
+	return res, nil
+}
+
+// chunk split the list into lists of the size. The last list may be smaller.
+//
+// Example usage: range chunk 3 .Products.
+func chunk(size any, l any) ([][]any, error) {
+	n := toInt(size)
+	if n <= 0 {
+		return nil, fmt.Errorf("chunk: size must be positive, got %d", n)
+	}
+	items, err := toList("chunk", l)
+	if err != nil {
+		return nil, err
+	}
+	res := make([][]any, 0, (len(items)+n-1)/n)
+	for i := 0; i < len(items); i += n {
+		res = append(res, items[i:min(i+n, len(items))])
+	}
+	return res, nil
+}
+
+// has return whether the list contains the item, or the map contains the key.
+//
+// Example usage: has "admin" .Roles.
+func has(item any, l any) (bool, error) {
+	rv := reflect.Indirect(reflect.ValueOf(l))
+	if rv.Kind() == reflect.Map {
+		for _, key := range rv.MapKeys() {
+			if equalItems(key.Interface(), item) {
+				return true, nil
+			}
+		}
+		return false, nil
+	}
+	items, err := toList("has", l)
+	if err != nil {
+		return false, err
+	}
+	return containsItem(items, item), nil
+}
+
+// merge return a new map of all entries of the maps, later maps override the earlier ones.
+//
+// Example usage: merge .Defaults .Options.
+func merge(maps ...any) (map[string]any, error) {
+	res := make(map[string]any)
+	for _, m := range maps {
+		rv, err := toMap("merge", m)
+		if err != nil {
+			return nil, err
+		}
+		if !rv.IsValid() {
+			continue
+		}
+		iter := rv.MapRange()
+		for iter.Next() {
+			res[strval(iter.Key().Interface())] = iter.Value().Interface()
+		}
+	}
+	return res, nil
+}
+
+// toList convert a slice or array to []any. Nil is converted to an empty list.
+func toList(fn string, l any) ([]any, error) {
+	if items, ok := l.([]any); ok {
+		return items, nil
+	}
+	rv := reflect.ValueOf(l)
+	for rv.Kind() == reflect.Pointer || rv.Kind() == reflect.Interface {
+		if rv.IsNil() {
+			return []any{}, nil
+		}
+		rv = rv.Elem()
+	}
+	switch rv.Kind() {
+	case reflect.Invalid:
+		return []any{}, nil
+	case reflect.Slice, reflect.Array:
+		items := make([]any, rv.Len())
+		for i := range items {
+			items[i] = rv.Index(i).Interface()
+		}
+		return items, nil
+	default:
+		return nil, fmt.Errorf("%s: expected slice or array, got %T", fn, l)
+	}
+}
+
+// toMap return the map value. Nil is returned as an invalid value without error.
+func toMap(fn string, m any) (reflect.Value, error) {
+	rv := reflect.ValueOf(m)
+	for rv.Kind() == reflect.Pointer || rv.Kind() == reflect.Interface {
+		if rv.IsNil() {
+			return reflect.Value{}, nil
+		}
+		rv = rv.Elem()
+	}
+	switch rv.Kind() {
+	case reflect.Invalid:
+		return reflect.Value{}, nil
+	case reflect.Map:
+		return rv, nil
+	default:
+		return reflect.Value{}, fmt.Errorf("%s: expected map, got %T", fn, m)
+	}
+}
+
+func sortedKeys(rv reflect.Value) []reflect.Value {
+	keys := rv.MapKeys()
+	sort.Slice(keys, func(i, j int) bool {
+		return compareItems(keys[i].Interface(), keys[j].Interface()) < 0
+	})
+	return keys
+}
+
+// fieldOf return the value of the field of the item, which is a struct field, a map key or a method without arguments.
+// Nested fields are separated by dot. An empty field returns the item itself.
+func fieldOf(item any, field string) (any, bool) {
+	if field == "" {
+		return item, true
+	}
+	current := item
+	for _, name := range strings.Split(field, ".") {
+		value, ok := fieldOfValue(reflect.ValueOf(current), name)
+		if !ok {
+			return nil, false
+		}
+		current = value
+	}
+	return current, true
+}
+
+// fieldOfValue return the struct field or map key of the value,
+// or the result of the method without arguments if there is no such field or key.
+func fieldOfValue(rv reflect.Value, name string) (any, bool) {
+	if !rv.IsValid() || (rv.Kind() == reflect.Pointer || rv.Kind() == reflect.Interface) && rv.IsNil() {
+		return nil, false
+	}
+	value := rv
+	for value.Kind() == reflect.Pointer || value.Kind() == reflect.Interface {
+		if value.IsNil() {
+			return nil, false
+		}
+		value = value.Elem()
+	}
+	switch value.Kind() {
+	case reflect.Struct:
+		if field, ok := value.Type().FieldByName(name); ok && field.IsExported() {
+			if v, err := value.FieldByIndexErr(field.Index); err == nil {
+				return v.Interface(), true
+			}
+		}
+	case reflect.Map:
+		if key := value.Type().Key(); key.Kind() == reflect.String {
+			if v := value.MapIndex(reflect.ValueOf(name).Convert(key)); v.IsValid() {
+				return v.Interface(), true
+			}
+		}
+	}
+	if method := rv.MethodByName(name); method.IsValid() && method.Type().NumIn() == 0 && method.Type().NumOut() > 0 {
+		return method.Call(nil)[0].Interface(), true
+	}
+	return nil, false
+}
+
+func containsItem(items []any, item any) bool {
+	for _, v := range items {
+		if equalItems(v, item) {
+			return true
+		}
+	}
+	return false
+}
+
+// equalItems compare numbers by value, strings with the string form of the other value, and others deeply.
+func equalItems(a, b any) bool {
+	a, b = indirect(a), indirect(b)
+	if isNumber(a) && isNumber(b) {
+		return numberOf(a) == numberOf(b)
+	}
+	_, aString := a.(string)
+	_, bString := b.(string)
+	if (aString || bString) && a != nil && b != nil {
+		return strval(a) == strval(b)
+	}
+	return reflect.DeepEqual(a, b)
+}
+
+// compareItems compare numbers, strings, times and booleans. Nil is the smallest.
+// Values of different types are compared by their string form.
+func compareItems(a, b any) int {
+	a, b = indirect(a), indirect(b)
+	switch {
+	case a == nil && b == nil:
+		return 0
+	case a == nil:
+		return -1
+	case b == nil:
+		return 1
+	case isNumber(a) && isNumber(b):
+		x, y := numberOf(a), numberOf(b)
+		switch {
+		case x < y:
+			return -1
+		case x > y:
+			return 1
+		}
+		return 0
+	}
+	switch x := a.(type) {
+	case time.Time:
+		if y, ok := b.(time.Time); ok {
+			return x.Compare(y)
+		}
+	case bool:
+		if y, ok := b.(bool); ok {
+			switch {
+			case x == y:
+				return 0
+			case !x:
+				return -1
+			}
+			return 1
+		}
+	}
+	return strings.Compare(strval(a), strval(b))
+}
+
+// numberOf convert a value of numeric kind to float64, including named types.
+func numberOf(v any) float64 {
+	rv := reflect.ValueOf(v)
+	switch {
+	case rv.CanInt():
+		return float64(rv.Int())
+	case rv.CanUint():
+		return float64(rv.Uint())
+	case rv.CanFloat():
+		return rv.Float()
+	}
+	return 0
+}
+
+func isNumber(v any) bool {
+	switch reflect.ValueOf(v).Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr,
+		reflect.Float32, reflect.Float64:
+		return true
+	}
+	return false
+}
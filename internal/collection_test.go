@@ -0,0 +1,423 @@
+package internal
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+	"text/template"
+	"time"
+)
+
+type testUser struct {
+	Name    string
+	Age     int
+	Role    string
+	Profile *testProfile
+	secret  string
+}
+
+type testProfile struct {
+	City string
+}
+
+func (u testUser) Initial() string {
+	return u.Name[:1]
+}
+
+type testStatus int
+
+func testUsers() []testUser {
+	return []testUser{
+		{Name: "Carol", Age: 35, Role: "admin", Profile: &testProfile{City: "Hanoi"}},
+		{Name: "alice", Age: 30, Role: "user", Profile: &testProfile{City: "Paris"}},
+		{Name: "Bob", Age: 30, Role: "user"},
+	}
+}
+
+func names(items []any) []string {
+	res := make([]string, 0, len(items))
+	for _, item := range items {
+		switch item := item.(type) {
+		case testUser:
+			res = append(res, item.Name)
+		case *testUser:
+			res = append(res, item.Name)
+		case map[string]any:
+			res = append(res, item["name"].(string))
+		default:
+			res = append(res, strval(item))
+		}
+	}
+	return res
+}
+
+func TestList(t *testing.T) {
+	if l := list(); len(l) != 0 {
+		t.Fatalf("list = %v", l)
+	}
+	if l := list(1, "a", nil); !reflect.DeepEqual(l, []any{1, "a", nil}) {
+		t.Fatalf("list 1 a nil = %v", l)
+	}
+}
+
+func TestAppend(t *testing.T) {
+	base := make([]any, 2, 10)
+	base[0], base[1] = 1, 2
+	l, err := push(base, 3, 4)
+	if err != nil || !reflect.DeepEqual(l, []any{1, 2, 3, 4}) {
+		t.Fatalf("append = %v, %v", l, err)
+	}
+	other, _ := push(base, 5)
+	if l[2] != 3 || !reflect.DeepEqual(other, []any{1, 2, 5}) {
+		t.Fatalf("append modified the original list: %v, %v", l, other)
+	}
+	if l, err := push([]string{"a"}, "b"); err != nil || !reflect.DeepEqual(l, []any{"a", "b"}) {
+		t.Fatalf("append []string = %v, %v", l, err)
+	}
+	if l, err := push(nil, 1); err != nil || !reflect.DeepEqual(l, []any{1}) {
+		t.Fatalf("append nil = %v, %v", l, err)
+	}
+	if _, err := push(1, 2); err == nil {
+		t.Fatalf("append to int must fail")
+	}
+}
+
+func TestFirstLast(t *testing.T) {
+	arr := [3]int{1, 2, 3}
+	tests := []struct {
+		l           any
+		first, last any
+	}{
+		{[]int{1, 2, 3}, 1, 3},
+		{arr, 1, 3},
+		{&arr, 1, 3},
+		{[]string{"a"}, "a", "a"},
+		{[]any{}, nil, nil},
+		{nil, nil, nil},
+		{(*[]int)(nil), nil, nil},
+	}
+	for _, tt := range tests {
+		f, err := first(tt.l)
+		if err != nil || f != tt.first {
+			t.Fatalf("first %v = %v, %v", tt.l, f, err)
+		}
+		l, err := last(tt.l)
+		if err != nil || l != tt.last {
+			t.Fatalf("last %v = %v, %v", tt.l, l, err)
+		}
+	}
+	if _, err := first("abc"); err == nil {
+		t.Fatalf("first of string must fail")
+	}
+	if _, err := last(map[string]int{}); err == nil {
+		t.Fatalf("last of map must fail")
+	}
+}
+
+func TestSublist(t *testing.T) {
+	tests := []struct {
+		l       any
+		indices []any
+		want    any
+	}{
+		{[]int{1, 2, 3, 4}, []any{1, 3}, []any{2, 3}},
+		{[]int{1, 2, 3, 4}, []any{2}, []any{3, 4}},
+		{[]int{1, 2, 3, 4}, nil, []any{1, 2, 3, 4}},
+		{[]int{1, 2, 3, 4}, []any{"1", int64(2)}, []any{2}},
+		{[]int{1, 2, 3, 4}, []any{-1, 10}, []any{1, 2, 3, 4}},
+		{[]int{1, 2, 3, 4}, []any{3, 1}, []any{}},
+		{[]int{1, 2}, []any{5}, []any{}},
+		{"héllo", []any{1, 3}, "él"},
+		{"héllo", []any{3, 100}, "lo"},
+		{nil, []any{1}, []any{}},
+	}
+	for _, tt := range tests {
+		got, err := sublist(tt.l, tt.indices...)
+		if err != nil || !reflect.DeepEqual(got, tt.want) {
+			t.Fatalf("sublist %v %v = %#v, %v", tt.l, tt.indices, got, err)
+		}
+	}
+	if _, err := sublist(1, 0); err == nil {
+		t.Fatalf("sublist of int must fail")
+	}
+}
+
+func TestStandardSlice(t *testing.T) {
+	tmpl := template.Must(template.New("test").Funcs(NewBuiltinFuncMap()).Parse(
+		`{{ slice .L 1 2 3 | len }} {{ slice .S 1 2 }} {{ sublist .L 1 2 }}`))
+	var sb strings.Builder
+	if err := tmpl.Execute(&sb, map[string]any{"L": []int{1, 2, 3, 4}, "S": "héllo"}); err != nil {
+		t.Fatalf("execute: %v", err)
+	}
+	if want := "1 \xc3 [2]"; sb.String() != want {
+		t.Fatalf("execute = %q, want %q", sb.String(), want)
+	}
+}
+
+func TestReverse(t *testing.T) {
+	original := []any{1, 2, 3}
+	l, err := reverse(original)
+	if err != nil || !reflect.DeepEqual(l, []any{3, 2, 1}) {
+		t.Fatalf("reverse = %v, %v", l, err)
+	}
+	if original[0] != 1 {
+		t.Fatalf("reverse modified the original list: %v", original)
+	}
+	if l, err := reverse([]string{}); err != nil || len(l) != 0 {
+		t.Fatalf("reverse empty = %v, %v", l, err)
+	}
+	if _, err := reverse(map[string]int{}); err == nil {
+		t.Fatalf("reverse of map must fail")
+	}
+}
+
+func TestUniq(t *testing.T) {
+	tests := []struct {
+		l    any
+		want []any
+	}{
+		{[]int{1, 2, 1, 3, 2}, []any{1, 2, 3}},
+		{[]string{"b", "a", "b"}, []any{"b", "a"}},
+		{[]any{1, "1", nil, nil}, []any{1, nil}},
+		{[]any{1, int64(1), 2.0, uint8(2)}, []any{1, 2.0}},
+		{[][]int{{1}, {2}, {1}}, []any{[]int{1}, []int{2}}},
+		{[]struct{ V any }{{[]int{1}}, {[]int{1}}, {"a"}}, []any{struct{ V any }{[]int{1}}, struct{ V any }{"a"}}},
+		{nil, []any{}},
+	}
+	for _, tt := range tests {
+		got, err := uniq(tt.l)
+		if err != nil || !reflect.DeepEqual(got, tt.want) {
+			t.Fatalf("uniq %v = %v, %v", tt.l, got, err)
+		}
+	}
+	if _, err := uniq("abc"); err == nil {
+		t.Fatalf("uniq of string must fail")
+	}
+}
+
+func TestSortBy(t *testing.T) {
+	users := testUsers()
+	tests := []struct {
+		field string
+		l     any
+		want  []string
+	}{
+		{"Name", users, []string{"Bob", "Carol", "alice"}},
+		{"-Name", users, []string{"alice", "Carol", "Bob"}},
+		{"Age", users, []string{"alice", "Bob", "Carol"}},
+		{"-Age", users, []string{"Carol", "alice", "Bob"}},
+		{"Initial", users, []string{"Bob", "Carol", "alice"}},
+		{"Profile.City", users, []string{"Bob", "Carol", "alice"}},
+		{"Missing", users, []string{"Carol", "alice", "Bob"}},
+		{"Name", []*testUser{&users[0], &users[1]}, []string{"Carol", "alice"}},
+		{"name", []map[string]any{{"name": "b"}, {"name": "a"}}, []string{"a", "b"}},
+		{"", []int{3, 1, 2}, []string{"1", "2", "3"}},
+		{"-", []float64{1.5, 10, 2}, []string{"10", "2", "1.5"}},
+		{"", []testStatus{2, 10, 1}, []string{"1", "2", "10"}},
+		{"", []any{"b", nil, "a"}, []string{"<nil>", "a", "b"}},
+		{"", []bool{true, false}, []string{"false", "true"}},
+		{"", []time.Time{time.Unix(2, 0).UTC(), time.Unix(1, 0).UTC()}, []string{
+			time.Unix(1, 0).UTC().String(), time.Unix(2, 0).UTC().String(),
+		}},
+	}
+	for _, tt := range tests {
+		got, err := sortBy(tt.field, tt.l)
+		if err != nil || !reflect.DeepEqual(names(got), tt.want) {
+			t.Fatalf("sortBy %q = %v, %v, want %v", tt.field, names(got), err, tt.want)
+		}
+	}
+	if users[0].Name != "Carol" {
+		t.Fatalf("sortBy modified the original list")
+	}
+	if _, err := sortBy("Name", testUser{}); err == nil {
+		t.Fatalf("sortBy of struct must fail")
+	}
+}
+
+func TestGroupBy(t *testing.T) {
+	groups, err := groupBy("Role", testUsers())
+	if err != nil {
+		t.Fatalf("groupBy: %v", err)
+	}
+	if len(groups) != 2 || !reflect.DeepEqual(names(groups["user"]), []string{"alice", "Bob"}) ||
+		!reflect.DeepEqual(names(groups["admin"]), []string{"Carol"}) {
+		t.Fatalf("groupBy Role = %v", groups)
+	}
+	groups, err = groupBy("Age", testUsers())
+	if err != nil || len(groups["30"]) != 2 || len(groups["35"]) != 1 {
+		t.Fatalf("groupBy Age = %v, %v", groups, err)
+	}
+	groups, err = groupBy("Profile.City", testUsers())
+	if err != nil || !reflect.DeepEqual(names(groups[""]), []string{"Bob"}) {
+		t.Fatalf("groupBy missing field = %v, %v", groups, err)
+	}
+	if _, err := groupBy("Role", 1); err == nil {
+		t.Fatalf("groupBy of int must fail")
+	}
+}
+
+func TestKeysValues(t *testing.T) {
+	m := map[string]int{"b": 2, "a": 1, "c": 3}
+	k, err := keys(m)
+	if err != nil || !reflect.DeepEqual(k, []any{"a", "b", "c"}) {
+		t.Fatalf("keys = %v, %v", k, err)
+	}
+	v, err := values(&m)
+	if err != nil || !reflect.DeepEqual(v, []any{1, 2, 3}) {
+		t.Fatalf("values = %v, %v", v, err)
+	}
+	k, err = keys(map[int]string{10: "x", 2: "y"})
+	if err != nil || !reflect.DeepEqual(k, []any{2, 10}) {
+		t.Fatalf("keys int = %v, %v", k, err)
+	}
+	if k, err := keys(nil); err != nil || len(k) != 0 {
+		t.Fatalf("keys nil = %v, %v", k, err)
+	}
+	if _, err := values([]int{1}); err == nil {
+		t.Fatalf("values of slice must fail")
+	}
+}
+
+func TestPluck(t *testing.T) {
+	tests := []struct {
+		field string
+		l     any
+		want  []any
+	}{
+		{"Name", testUsers(), []any{"Carol", "alice", "Bob"}},
+		{"Profile.City", testUsers(), []any{"Hanoi", "Paris"}},
+		{"secret", testUsers(), []any{}},
+		{"id", []map[string]int{{"id": 1}, {"other": 2}, {"id": 3}}, []any{1, 3}},
+		{"Initial", []*testUser{{Name: "Zed"}, nil}, []any{"Z"}},
+	}
+	for _, tt := range tests {
+		got, err := pluck(tt.field, tt.l)
+		if err != nil || !reflect.DeepEqual(got, tt.want) {
+			t.Fatalf("pluck %q = %#v, %v", tt.field, got, err)
+		}
+	}
+	if _, err := pluck("Name", "abc"); err == nil {
+		t.Fatalf("pluck of string must fail")
+	}
+}
+
+func TestWhere(t *testing.T) {
+	tests := []struct {
+		field string
+		value any
+		want  []string
+	}{
+		{"Role", "user", []string{"alice", "Bob"}},
+		{"Age", 30, []string{"alice", "Bob"}},
+		{"Age", int64(35), []string{"Carol"}},
+		{"Age", 30.0, []string{"alice", "Bob"}},
+		{"Age", "35", []string{"Carol"}},
+		{"Profile.City", "Paris", []string{"alice"}},
+		{"Role", "none", []string{}},
+		{"Missing", nil, []string{}},
+	}
+	for _, tt := range tests {
+		got, err := where(tt.field, tt.value, testUsers())
+		if err != nil || !reflect.DeepEqual(names(got), tt.want) {
+			t.Fatalf("where %q %v = %v, %v", tt.field, tt.value, names(got), err)
+		}
+	}
+	got, err := where("Status", 2, []map[string]any{{"name": "a", "Status": testStatus(2)}, {"name": "b", "Status": testStatus(1)}})
+	if err != nil || !reflect.DeepEqual(names(got), []string{"a"}) {
+		t.Fatalf("where named number = %v, %v", names(got), err)
+	}
+	if _, err := where("Role", "user", 1); err == nil {
+		t.Fatalf("where of int must fail")
+	}
+}
+
+func TestChunk(t *testing.T) {
+	tests := []struct {
+		size any
+		l    any
+		want [][]any
+	}{
+		{2, []int{1, 2, 3, 4, 5}, [][]any{{1, 2}, {3, 4}, {5}}},
+		{"3", []int{1, 2, 3}, [][]any{{1, 2, 3}}},
+		{10, []int{1, 2}, [][]any{{1, 2}}},
+		{2, []int{}, [][]any{}},
+	}
+	for _, tt := range tests {
+		got, err := chunk(tt.size, tt.l)
+		if err != nil || !reflect.DeepEqual(got, tt.want) {
+			t.Fatalf("chunk %v %v = %v, %v", tt.size, tt.l, got, err)
+		}
+	}
+	if _, err := chunk(0, []int{1}); err == nil {
+		t.Fatalf("chunk 0 must fail")
+	}
+	if _, err := chunk(1, 1); err == nil {
+		t.Fatalf("chunk of int must fail")
+	}
+}
+
+func TestHas(t *testing.T) {
+	tests := []struct {
+		item any
+		l    any
+		want bool
+	}{
+		{"admin", []string{"user", "admin"}, true},
+		{"root", []string{"user", "admin"}, false},
+		{2, []int64{1, 2}, true},
+		{"2", []int{1, 2}, true},
+		{[]int{1}, [][]int{{1}, {2}}, true},
+		{"a", map[string]int{"a": 1}, true},
+		{"b", map[string]int{"a": 1}, false},
+		{1, nil, false},
+	}
+	for _, tt := range tests {
+		got, err := has(tt.item, tt.l)
+		if err != nil || got != tt.want {
+			t.Fatalf("has %v %v = %v, %v", tt.item, tt.l, got, err)
+		}
+	}
+	if _, err := has(1, "abc"); err == nil {
+		t.Fatalf("has in string must fail")
+	}
+}
+
+func TestMerge(t *testing.T) {
+	dst := map[string]any{"a": 1, "b": 2}
+	got, err := merge(dst, map[string]int{"b": 3, "c": 4}, nil)
+	if err != nil || !reflect.DeepEqual(got, map[string]any{"a": 1, "b": 3, "c": 4}) {
+		t.Fatalf("merge = %v, %v", got, err)
+	}
+	if dst["b"] != 2 {
+		t.Fatalf("merge modified the original map")
+	}
+	if got, err := merge(); err != nil || len(got) != 0 {
+		t.Fatalf("merge nothing = %v, %v", got, err)
+	}
+	if _, err := merge(dst, []int{1}); err == nil {
+		t.Fatalf("merge slice must fail")
+	}
+}
+
+func TestCollectionTemplate(t *testing.T) {
+	tmpl := template.Must(template.New("test").Funcs(NewBuiltinFuncMap()).Parse(
+		`{{ range sortBy "-Age" .Users }}{{ .Name }},{{ end }}` +
+			`|{{ slice (list 1 2 3) 1 }}` +
+			`|{{ pluck "Name" (where "Role" "user" .Users) }}` +
+			`|{{ range $k, $v := groupBy "Role" .Users }}{{ $k }}={{ len $v }};{{ end }}` +
+			`|{{ if has 30 (pluck "Age" .Users) }}yes{{ end }}`,
+	))
+	var sb strings.Builder
+	err := tmpl.Execute(&sb, map[string]any{"Users": testUsers()})
+	if err != nil {
+		t.Fatalf("execute: %v", err)
+	}
+	if want := "Carol,alice,Bob,|[2 3]|[alice Bob]|admin=1;user=2;|yes"; sb.String() != want {
+		t.Fatalf("render = %q, want %q", sb.String(), want)
+	}
+
+	err = template.Must(template.New("test").Funcs(NewBuiltinFuncMap()).Parse(`{{ first 1 }}`)).Execute(&sb, nil)
+	if err == nil || !strings.Contains(err.Error(), "first: expected slice or array, got int") {
+		t.Fatalf("execute first 1 error = %v", err)
+	}
+}
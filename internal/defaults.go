@@ -0,0 +1,76 @@
+package internal
+
+import (
+	"errors"
+	"reflect"
+)
+
+// defaultValue return the value, or the default if the value is empty (see [empty]).
+// The value is optional, so a missing value also returns the default.
+//
+// Example usage: .Title | default "Untitled".
+func defaultValue(def any, v ...any) any {
+	if len(v) == 0 || empty(v[0]) {
+		return def
+	}
+	return v[0]
+}
+
+// coalesce return the first non-empty value, or nil if all values are empty.
+//
+// Example usage: coalesce .Nickname .Name "Anonymous".
+func coalesce(v ...any) any {
+	for _, item := range v {
+		if !empty(item) {
+			return item
+		}
+	}
+	return nil
+}
+
+// empty return whether the value is empty: nil, false, zero number,
+// empty string, slice, array or map, or nil pointer. Structs are never empty.
+//
+// Example usage: if empty .Items.
+func empty(v any) bool {
+	rv := reflect.ValueOf(v)
+	if !rv.IsValid() {
+		return true
+	}
+	switch rv.Kind() {
+	case reflect.String, reflect.Slice, reflect.Array, reflect.Map, reflect.Chan:
+		return rv.Len() == 0
+	case reflect.Bool:
+		return !rv.Bool()
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return rv.Int() == 0
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return rv.Uint() == 0
+	case reflect.Float32, reflect.Float64:
+		return rv.Float() == 0
+	case reflect.Complex64, reflect.Complex128:
+		return rv.Complex() == 0
+	case reflect.Struct:
+		return false
+	default:
+		return rv.IsNil()
+	}
+}
+
+// required return the value, or fail the template execution with the message if the value is empty (see [empty]).
+// Pointers are dereferenced, so a pointer to an empty value is also missing.
+//
+// Example usage: required "title is required" .Title.
+func required(msg string, v any) (any, error) {
+	if empty(indirect(v)) {
+		return nil, errors.New(msg)
+	}
+	return v, nil
+}
+
+// fail fail the template execution with the message.
+//
+// Example usage: {{ if not .User }}{{ fail "user is missing" }}{{ end }}.
+func fail(msg string) (string, error) {
+	return "", errors.New(msg)
+}
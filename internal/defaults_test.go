@@ -0,0 +1,140 @@
+package internal
+
+import (
+	"strings"
+	"testing"
+	"text/template"
+)
+
+type testD map[string]any
+
+// Name is shadowed by the "Name" key, as map keys are checked before methods.
+func (d testD) Name() string {
+	return "method"
+}
+
+func TestDig(t *testing.T) {
+	data := map[string]any{
+		"user":   testD{"name": "Ana", "Name": "key", "address": map[string]string{"city": "Hanoi"}},
+		"guest":  testD{},
+		"author": &testUser{Name: "Bob", Profile: &testProfile{City: "Paris"}},
+		"title":  "x",
+		"none":   nil,
+	}
+	tests := []struct {
+		args []any
+		want any
+	}{
+		{[]any{"user", "name", "-", data}, "Ana"},
+		{[]any{"user", "Name", "-", data}, "key"},
+		{[]any{"guest", "Name", "-", data}, "method"},
+		{[]any{"user", "address", "city", "-", data}, "Hanoi"},
+		{[]any{"user", "address", "zip", "-", data}, "-"},
+		{[]any{"author", "Profile", "City", "-", data}, "Paris"},
+		{[]any{"author", "secret", "-", data}, "-"},
+		{[]any{"none", "name", "-", data}, "-"},
+		{[]any{"missing", "name", "-", data}, "-"},
+		{[]any{"name", "-", nil}, "-"},
+	}
+	for _, test := range tests {
+		got, err := dig(test.args...)
+		if err != nil {
+			t.Fatalf("dig %v: %v", test.args[:len(test.args)-1], err)
+		}
+		if got != test.want {
+			t.Fatalf("dig %v = %v, want %v", test.args[:len(test.args)-1], got, test.want)
+		}
+	}
+
+	for _, args := range [][]any{
+		{"user", data},
+		{"user", 1, "-", data},
+		{"title", "name", "-", data},
+		{"name", "-", "x"},
+	} {
+		if _, err := dig(args...); err == nil {
+			t.Fatalf("dig %v must fail", args)
+		}
+	}
+}
+
+func TestEmpty(t *testing.T) {
+	var nilUser *testUser
+	for _, v := range []any{nil, "", 0, 0.0, uint8(0), false, []int{}, map[string]any{}, nilUser, testStatus(0)} {
+		if !empty(v) {
+			t.Fatalf("empty %#v = false, want true", v)
+		}
+	}
+	for _, v := range []any{"a", 1, -1.5, true, []int{0}, map[string]any{"a": nil}, &testUser{}, testUser{}} {
+		if empty(v) {
+			t.Fatalf("empty %#v = true, want false", v)
+		}
+	}
+}
+
+func TestDefaultAndCoalesce(t *testing.T) {
+	if got := defaultValue("x"); got != "x" {
+		t.Fatalf("default without value = %v", got)
+	}
+	if got := defaultValue("x", ""); got != "x" {
+		t.Fatalf("default empty = %v", got)
+	}
+	if got := defaultValue("x", 0.5); got != 0.5 {
+		t.Fatalf("default value = %v", got)
+	}
+	if got := coalesce(nil, "", 0, "a", "b"); got != "a" {
+		t.Fatalf("coalesce = %v", got)
+	}
+	if got := coalesce(nil, ""); got != nil {
+		t.Fatalf("coalesce empty = %v", got)
+	}
+}
+
+func TestRequired(t *testing.T) {
+	var nilUser *testUser
+	emptyName := ""
+	for _, v := range []any{nil, "", nilUser, 0, false, []int{}, map[string]int{}, &emptyName} {
+		if _, err := required("missing", v); err == nil || err.Error() != "missing" {
+			t.Fatalf("required %#v err = %v", v, err)
+		}
+	}
+	for _, v := range []any{1, true, "a", &testUser{}, testUser{}} {
+		if got, err := required("missing", v); err != nil || got != v {
+			t.Fatalf("required %#v = %v, %v", v, got, err)
+		}
+	}
+}
+
+func TestDefaultsTemplate(t *testing.T) {
+	funcs := NewBuiltinFuncMap()
+	tests := []struct {
+		text    string
+		want    string
+		wantErr string
+	}{
+		{`{{ .Missing | default "Untitled" }}`, "Untitled", ""},
+		{`{{ coalesce .Nick .Name }}`, "Ana", ""},
+		{`{{ if empty .Items }}none{{ end }}`, "none", ""},
+		{`{{ required "title is required" .Title }}`, "", "title is required"},
+		{`{{ if not .Title }}{{ fail "no title" }}{{ end }}`, "", "no title"},
+		{`{{ dig "a" "-" }}`, "", "dig: expected at least three arguments, got 2"},
+		{`{{ div 1 0 }}`, "", "div: division by zero"},
+	}
+	for _, test := range tests {
+		var sb strings.Builder
+		err := template.Must(template.New("test").Funcs(funcs).Parse(test.text)).
+			Execute(&sb, map[string]any{"Name": "Ana", "Nick": "", "Title": ""})
+		if test.wantErr != "" {
+			if err == nil || !strings.Contains(err.Error(), test.wantErr) {
+				t.Fatalf("execute %s err = %v, want %q", test.text, err, test.wantErr)
+			}
+			continue
+		}
+		if err != nil {
+			t.Fatalf("execute %s: %v", test.text, err)
+		}
+		if got := sb.String(); got != test.want {
+			t.Fatalf("execute %s = %q, want %q", test.text, got, test.want)
+		}
+	}
+}
@@ -0,0 +1,136 @@
+package internal
+
+import (
+	"bytes"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"strings"
+)
+
+// NewEncodingFuncMap returns the functions for encoding and hashing, available in both HTML and text mode.
+func NewEncodingFuncMap(excludes ...string) map[string]any {
+	funcs := map[string]any{
+		"b64enc":       b64enc,
+		"b64dec":       b64dec,
+		"urlencode":    urlencode,
+		"urldecode":    urldecode,
+		"sha256sum":    sha256sum,
+		"hmac":         hmacSHA256,
+		"uuid":         uuid,
+		"toJSON":       toJSON,
+		"fromJSON":     fromJSON,
+		"toPrettyJSON": toPrettyJSON,
+	}
+	for _, name := range excludes {
+		delete(funcs, name)
+	}
+	return funcs
+}
+
+// b64enc encode the string using standard base64.
+//
+// Example usage: b64enc "hello" => aGVsbG8=.
+func b64enc(v any) string {
+	return base64.StdEncoding.EncodeToString([]byte(strval(v)))
+}
+
+// b64dec decode the standard base64 string.
+//
+// Example usage: b64dec "aGVsbG8=" => hello.
+func b64dec(v any) (string, error) {
+	b, err := base64.StdEncoding.DecodeString(strval(v))
+	if err != nil {
+		return "", fmt.Errorf("b64dec: %w", err)
+	}
+	return string(b), nil
+}
+
+// urlencode escape the string for use in URL query.
+//
+// Example usage: urlencode "a b&c" => a+b%26c.
+func urlencode(v any) string {
+	return url.QueryEscape(strval(v))
+}
+
+// urldecode unescape the URL query string.
+//
+// Example usage: urldecode "a+b%26c" => a b&c.
+func urldecode(v any) (string, error) {
+	s, err := url.QueryUnescape(strval(v))
+	if err != nil {
+		return "", fmt.Errorf("urldecode: %w", err)
+	}
+	return s, nil
+}
+
+// sha256sum return the hex encoded SHA-256 hash of the string.
+//
+// Example usage: sha256sum .Email.
+func sha256sum(v any) string {
+	sum := sha256.Sum256([]byte(strval(v)))
+	return hex.EncodeToString(sum[:])
+}
+
+// hmacSHA256 return the hex encoded HMAC-SHA256 of the string using the key.
+//
+// Example usage: .Path | hmac .Secret.
+func hmacSHA256(key any, v any) string {
+	mac := hmac.New(sha256.New, []byte(strval(key)))
+	mac.Write([]byte(strval(v)))
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+// uuid return a random (version 4) UUID.
+//
+// Example usage: uuid => 0b5d0f1e-8c5a-4c4b-9f6e-2d1c3b4a5f6e.
+func uuid() string {
+	var b [16]byte
+	_, _ = rand.Read(b[:])
+	b[6] = b[6]&0x0f | 0x40
+	b[8] = b[8]&0x3f | 0x80
+	return fmt.Sprintf("%x-%x-%x-%x-%x", b[0:4], b[4:6], b[6:8], b[8:10], b[10:])
+}
+
+// toJSON encode the value as JSON string.
+// Unlike the toJSON of [NewSafeFuncMap], the characters <, > and & are not escaped,
+// and the result is a plain string which is escaped by html/template like any other string.
+//
+// Example usage: toJSON .Payload.
+func toJSON(v any) (string, error) {
+	return encodeJSON(v, "")
+}
+
+// toPrettyJSON encode the value as indented JSON string.
+//
+// Example usage: toPrettyJSON .Config.
+func toPrettyJSON(v any) (string, error) {
+	return encodeJSON(v, "  ")
+}
+
+// fromJSON decode the JSON string. Objects are decoded as map[string]any and numbers as float64.
+//
+// Example usage: (fromJSON .Raw).name.
+func fromJSON(v any) (any, error) {
+	var res any
+	if err := json.Unmarshal([]byte(strval(v)), &res); err != nil {
+		return nil, fmt.Errorf("fromJSON: %w", err)
+	}
+	return res, nil
+}
+
+func encodeJSON(v any, indent string) (string, error) {
+	var buf bytes.Buffer
+	encoder := json.NewEncoder(&buf)
+	encoder.SetEscapeHTML(false)
+	encoder.SetIndent("", indent)
+	if err := encoder.Encode(v); err != nil {
+		return "", err
+	}
+	return strings.TrimSuffix(buf.String(), "\n"), nil
+}
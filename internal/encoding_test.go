@@ -0,0 +1,53 @@
+package internal
+
+import (
+	"regexp"
+	"strings"
+	"testing"
+	"text/template"
+)
+
+func TestEncodingFuncs(t *testing.T) {
+	tests := []struct {
+		text string
+		data any
+		want string
+	}{
+		{`{{ b64enc . }}`, "hello", "aGVsbG8="},
+		{`{{ b64dec . }}`, "aGVsbG8=", "hello"},
+		{`{{ urlencode . }}`, "a b&c/d", "a+b%26c%2Fd"},
+		{`{{ urldecode . }}`, "a+b%26c", "a b&c"},
+		{`{{ sha256sum . }}`, "abc", "ba7816bf8f01cfea414140de5dae2223b00361a396177a9cb410ff61f20015ad"},
+		{`{{ . | hmac "key" }}`, "The quick brown fox jumps over the lazy dog",
+			"f7bc83f430538424b13298e6aa6fb143ef4d59a14946175997479dbc2d1a3cd8"},
+		{`{{ toJSON . }}`, map[string]any{"a": "<&>", "b": []int{1}}, `{"a":"<&>","b":[1]}`},
+		{`{{ toPrettyJSON . }}`, map[string]any{"a": 1}, "{\n  \"a\": 1\n}"},
+		{`{{ (fromJSON .).name }} {{ (fromJSON .).tags }}`, `{"name":"Ana","tags":["a",1]}`, "Ana [a 1]"},
+	}
+	for _, tt := range tests {
+		tmpl := template.Must(template.New("test").Funcs(NewEncodingFuncMap()).Parse(tt.text))
+		var sb strings.Builder
+		if err := tmpl.Execute(&sb, tt.data); err != nil {
+			t.Fatalf("execute %s: %v", tt.text, err)
+		}
+		if got := sb.String(); got != tt.want {
+			t.Fatalf("execute %s = %q, want %q", tt.text, got, tt.want)
+		}
+	}
+
+	for _, text := range []string{`{{ b64dec "%%" }}`, `{{ urldecode "%zz" }}`, `{{ fromJSON "{" }}`, `{{ toJSON . }}`} {
+		tmpl := template.Must(template.New("test").Funcs(NewEncodingFuncMap()).Parse(text))
+		var sb strings.Builder
+		if err := tmpl.Execute(&sb, func() {}); err == nil {
+			t.Fatalf("execute %s must fail", text)
+		}
+	}
+}
+
+func TestUUID(t *testing.T) {
+	pattern := regexp.MustCompile(`^[0-9a-f]{8}-[0-9a-f]{4}-4[0-9a-f]{3}-[89ab][0-9a-f]{3}-[0-9a-f]{12}$`)
+	a, b := uuid(), uuid()
+	if !pattern.MatchString(a) || a == b {
+		t.Fatalf("uuid = %q, %q", a, b)
+	}
+}
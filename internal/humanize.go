@@ -0,0 +1,170 @@
+package internal
+
+import (
+	"math"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+	"unicode"
+)
+
+// timeago format the time relative to now.
+// Numbers are unix seconds, strings are parsed as RFC 3339, and durations are the time elapsed.
+// Returns an empty string for nil, zero time (such as an unset CreatedAt) and invalid strings.
+//
+// Example usage: timeago .CreatedAt => 3 months ago, timeago .ExpiredAt => in 2 days.
+func timeago(v any) string {
+	var d time.Duration
+	switch v := indirect(v).(type) {
+	case time.Time:
+		if v.IsZero() {
+			return ""
+		}
+		d = time.Since(v)
+	case time.Duration:
+		d = v
+	case string:
+		t, err := time.Parse(time.RFC3339, v)
+		if err != nil || t.IsZero() {
+			return ""
+		}
+		d = time.Since(t)
+	case nil:
+		return ""
+	default:
+		d = time.Since(time.Unix(int64(toFloat64(v)), 0))
+	}
+
+	future := d < 0
+	if future {
+		d = -d
+	}
+	var n int64
+	var unit string
+	switch {
+	case d < time.Minute:
+		return "just now"
+	case d < time.Hour:
+		n, unit = int64(d/time.Minute), "minute"
+	case d < 24*time.Hour:
+		n, unit = int64(d/time.Hour), "hour"
+	case d < 30*24*time.Hour:
+		n, unit = int64(d/(24*time.Hour)), "day"
+	case d < 365*24*time.Hour:
+		n, unit = int64(d/(30*24*time.Hour)), "month"
+	default:
+		n, unit = int64(d/(365*24*time.Hour)), "year"
+	}
+	res := strconv.FormatInt(n, 10) + " " + unit
+	if n != 1 {
+		res += "s"
+	}
+	if future {
+		return "in " + res
+	}
+	return res + " ago"
+}
+
+// humanBytes format a byte size using SI units (1 kB = 1000 B).
+// If binary is true, IEC units are used instead (1 KiB = 1024 B).
+//
+// Example usage: bytes 1400000 => 1.4 MB, bytes 1536 true => 1.5 KiB.
+func humanBytes(v any, binary ...bool) string {
+	n := toFloat64(v)
+	base, units := 1000.0, []string{"B", "kB", "MB", "GB", "TB", "PB", "EB"}
+	if len(binary) > 0 && binary[0] {
+		base, units = 1024.0, []string{"B", "KiB", "MiB", "GiB", "TiB", "PiB", "EiB"}
+	}
+
+	i := 0
+	value := n
+	for math.Abs(value) >= base && i < len(units)-1 {
+		value /= base
+		i++
+	}
+	if i == 0 {
+		return strconv.FormatFloat(value, 'f', 0, 64) + " B"
+	}
+	// Rounding can reach the next unit, such as 999.96 kB => 1000 kB.
+	if math.Abs(value) < 10 {
+		value = math.Round(value*10) / 10
+	} else {
+		value = math.Round(value)
+	}
+	if math.Abs(value) >= base && i < len(units)-1 {
+		value /= base
+		i++
+	}
+	return strconv.FormatFloat(value, 'f', -1, 64) + " " + units[i]
+}
+
+// ordinal format a number as English ordinal.
+//
+// Example usage: ordinal 3 => 3rd, ordinal 11 => 11th.
+func ordinal(v any) string {
+	n := toInt(v)
+	suffix := "th"
+	abs := n
+	if abs < 0 {
+		abs = -abs
+	}
+	if abs%100 < 11 || abs%100 > 13 {
+		switch abs % 10 {
+		case 1:
+			suffix = "st"
+		case 2:
+			suffix = "nd"
+		case 3:
+			suffix = "rd"
+		}
+	}
+	return strconv.Itoa(n) + suffix
+}
+
+// plural format the count with the singular or plural form.
+// If the count is a slice, array or map, its length is used.
+//
+// Example usage: plural "item" "items" 1 => 1 item, plural "item" "items" .Items => 2 items.
+func plural(singular string, pluralForm string, count any) string {
+	var n float64
+	switch rv := reflect.Indirect(reflect.ValueOf(count)); rv.Kind() {
+	case reflect.Slice, reflect.Array, reflect.Map:
+		n = float64(rv.Len())
+	default:
+		n = toFloat64(count)
+	}
+	res := strconv.FormatFloat(n, 'f', -1, 64) + " "
+	if n == 1 {
+		return res + singular
+	}
+	return res + pluralForm
+}
+
+// truncate shorten the string to at most length characters, cutting at word boundary when possible,
+// followed by an ellipsis.
+//
+// Example usage: .Body | truncate 20.
+func truncate(length any, v any) string {
+	s := strval(v)
+	n := toInt(length)
+	runes := []rune(s)
+	if n < 0 || len(runes) <= n {
+		return s
+	}
+
+	cut := runes[:n]
+	// Cut at the last space, unless it loses more than half of the text.
+	// The next character being a space means the cut is already at word boundary.
+	if !unicode.IsSpace(runes[n]) {
+		for i := len(cut) - 1; i > n/2; i-- {
+			if unicode.IsSpace(cut[i]) {
+				cut = cut[:i]
+				break
+			}
+		}
+	}
+	return strings.TrimRightFunc(string(cut), func(r rune) bool {
+		return unicode.IsSpace(r) || unicode.IsPunct(r)
+	}) + "…"
+}
@@ -0,0 +1,110 @@
+package internal
+
+import (
+	"testing"
+	"time"
+)
+
+func TestTimeago(t *testing.T) {
+	now := time.Now()
+	tests := []struct {
+		v    any
+		want string
+	}{
+		{now, "just now"},
+		{now.Add(-90 * time.Second), "1 minute ago"},
+		{&now, "just now"},
+		{now.Add(-3 * time.Hour), "3 hours ago"},
+		{now.Add(49 * time.Hour), "in 2 days"},
+		{now.Add(-95 * 24 * time.Hour), "3 months ago"},
+		{now.Add(-800 * 24 * time.Hour), "2 years ago"},
+		{5 * time.Minute, "5 minutes ago"},
+		{now.Add(-2 * time.Hour).Unix(), "2 hours ago"},
+		{now.Add(-25 * time.Hour).Format(time.RFC3339), "1 day ago"},
+		{"invalid", ""},
+		{nil, ""},
+		{time.Time{}, ""},
+		{&time.Time{}, ""},
+		{time.Time{}.Format(time.RFC3339), ""},
+	}
+	for _, tt := range tests {
+		if s := timeago(tt.v); s != tt.want {
+			t.Fatalf("timeago %v = %q, want %q", tt.v, s, tt.want)
+		}
+	}
+}
+
+func TestHumanBytes(t *testing.T) {
+	tests := []struct {
+		v      any
+		binary bool
+		want   string
+	}{
+		{512, false, "512 B"},
+		{"1400000", false, "1.4 MB"},
+		{1000, false, "1 kB"},
+		{12345678, false, "12 MB"},
+		{999960, false, "1 MB"},
+		{1536, true, "1.5 KiB"},
+		{int64(1 << 30), true, "1 GiB"},
+		{-2048, true, "-2 KiB"},
+	}
+	for _, tt := range tests {
+		if s := humanBytes(tt.v, tt.binary); s != tt.want {
+			t.Fatalf("bytes %v %v = %q, want %q", tt.v, tt.binary, s, tt.want)
+		}
+	}
+}
+
+func TestOrdinal(t *testing.T) {
+	tests := map[any]string{
+		1: "1st", 2: "2nd", 3: "3rd", 4: "4th", 11: "11th", 12: "12th", 13: "13th",
+		21: "21st", 102: "102nd", 111: "111th", "23": "23rd", -1: "-1st", 0: "0th",
+	}
+	for v, want := range tests {
+		if s := ordinal(v); s != want {
+			t.Fatalf("ordinal %v = %q, want %q", v, s, want)
+		}
+	}
+}
+
+func TestPlural(t *testing.T) {
+	tests := []struct {
+		count any
+		want  string
+	}{
+		{1, "1 item"},
+		{0, "0 items"},
+		{"2", "2 items"},
+		{1.5, "1.5 items"},
+		{[]string{"a"}, "1 item"},
+		{map[string]int{"a": 1, "b": 2}, "2 items"},
+	}
+	for _, tt := range tests {
+		if s := plural("item", "items", tt.count); s != tt.want {
+			t.Fatalf("plural %v = %q, want %q", tt.count, s, tt.want)
+		}
+	}
+}
+
+func TestTruncate(t *testing.T) {
+	tests := []struct {
+		length any
+		s      any
+		want   string
+	}{
+		{20, "short", "short"},
+		{10, "The quick brown fox", "The quick…"},
+		{9, "The quick brown fox", "The quick…"},
+		{12, "The quick, brown fox", "The quick…"},
+		{5, "Supercalifragilistic", "Super…"},
+		{"4", "Xin chào thế giới", "Xin…"},
+		{6, "héllo wörld", "héllo…"},
+		{-1, "unchanged", "unchanged"},
+	}
+	for _, tt := range tests {
+		if s := truncate(tt.length, tt.s); s != tt.want {
+			t.Fatalf("truncate %v %q = %q, want %q", tt.length, tt.s, s, tt.want)
+		}
+	}
+}
@@ -0,0 +1,257 @@
+package internal
+
+import (
+	"math"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// NewLocaleFuncMap returns the locale-aware functions of the locale (BCP 47 tag, such as "en" or "pt-BR").
+// Unknown locales fall back to their base language, then to English.
+func NewLocaleFuncMap(locale string, excludes ...string) map[string]any {
+	l := findLocale(locale)
+	funcs := map[string]any{
+		"numfmt":    l.number,
+		"percent":   l.percentage,
+		"currency":  l.money,
+		"compact":   l.compactNumber,
+		"monthName": l.monthName,
+		"dayName":   l.dayName,
+		"localdate": l.date,
+	}
+	for _, name := range excludes {
+		delete(funcs, name)
+	}
+	return funcs
+}
+
+// findLocale returns the data of the locale, its base language or the default locale.
+func findLocale(locale string) localeData {
+	if resolved := ResolveLocale(locale); resolved != "" {
+		return locales[resolved]
+	}
+	return locales[defaultLocale]
+}
+
+// ResolveLocale return the locale whose data is used for the given locale, such as "de" for "de-DE",
+// or an empty string if there is no data for the locale, or it is the default locale.
+func ResolveLocale(locale string) string {
+	locale = strings.ToLower(strings.ReplaceAll(locale, "_", "-"))
+	if _, ok := locales[locale]; !ok {
+		locale, _, _ = strings.Cut(locale, "-")
+		if _, ok := locales[locale]; !ok {
+			return ""
+		}
+	}
+	if locale == defaultLocale {
+		return ""
+	}
+	return locale
+}
+
+// number format a number with thousands separators.
+// If decimals is not set, the shortest representation is used.
+//
+// Example usage: numfmt 1234.5 => 1,234.5, numfmt 1234.5 2 => 1,234.50.
+func (l localeData) number(v any, decimals ...int) string {
+	prec := -1
+	if len(decimals) > 0 {
+		prec = decimals[0]
+	}
+	return l.formatFloat(toFloat64(v), prec)
+}
+
+// percentage format a ratio as percentage.
+//
+// Example usage: percent 0.256 => 26%, percent 0.256 1 => 25.6%.
+func (l localeData) percentage(v any, decimals ...int) string {
+	prec := 0
+	if len(decimals) > 0 {
+		prec = decimals[0]
+	}
+	return strings.Replace(l.percent, "#", l.formatFloat(toFloat64(v)*100, prec), 1)
+}
+
+// money format an amount with the currency symbol of the ISO 4217 code.
+//
+// Example usage: currency 1234.5 "USD" => $1,234.50.
+func (l localeData) money(v any, code string) string {
+	code = strings.ToUpper(code)
+	symbol, digits := code, 2
+	if c, ok := currencies[code]; ok {
+		symbol, digits = c.symbol, c.digits
+	}
+	amount := toFloat64(v)
+	sign := ""
+	if amount < 0 {
+		sign = "-"
+		amount = -amount
+	}
+	res := strings.Replace(l.currency, "#", l.formatFloat(amount, digits), 1)
+	return sign + strings.Replace(res, "¤", symbol, 1)
+}
+
+// compactNumber format a number in short form.
+//
+// Example usage: compact 1234 => 1.2K, compact 15300000 => 15M.
+func (l localeData) compactNumber(v any) string {
+	n := toFloat64(v)
+	abs := math.Abs(n)
+	unit := -1
+	for i, u := range l.compact {
+		if abs >= u.value {
+			unit = i
+		}
+	}
+	if unit < 0 {
+		return l.formatFloat(n, 0)
+	}
+
+	value, prec := l.compactValue(n, unit)
+	// Rounding can reach the next unit, such as 999999 => 1000K.
+	if unit+1 < len(l.compact) && math.Abs(value)*l.compact[unit].value >= l.compact[unit+1].value {
+		unit++
+		value, prec = l.compactValue(n, unit)
+	}
+	return l.formatFloat(value, prec) + l.compact[unit].suffix
+}
+
+// compactValue returns the rounded value of n in the unit, with 1 decimal for value less than 10.
+func (l localeData) compactValue(n float64, unit int) (float64, int) {
+	value := n / l.compact[unit].value
+	if math.Abs(value) < 10 {
+		value = math.Round(value*10) / 10
+		if value == math.Trunc(value) {
+			return value, 0
+		}
+		return value, 1
+	}
+	return math.Round(value), 0
+}
+
+// monthName returns the localized month name of a time or a month number (1-12).
+// If short is true, the abbreviated name is returned.
+//
+// Example usage: monthName (now), monthName 3 true.
+func (l localeData) monthName(v any, short ...bool) string {
+	var month int
+	if t, ok := toTime(v); ok {
+		month = int(t.Month())
+	} else {
+		month = toInt(v)
+	}
+	if month < 1 || month > 12 {
+		return ""
+	}
+	if len(short) > 0 && short[0] {
+		return l.shortMonths[month-1]
+	}
+	return l.months[month-1]
+}
+
+// dayName returns the localized weekday name of a time or a weekday number (0-6, starting from Sunday).
+// If short is true, the abbreviated name is returned.
+//
+// Example usage: dayName (now), dayName 1 true.
+func (l localeData) dayName(v any, short ...bool) string {
+	var day int
+	if t, ok := toTime(v); ok {
+		day = int(t.Weekday())
+	} else {
+		day = toInt(v)
+	}
+	if day < 0 || day > 6 {
+		return ""
+	}
+	if len(short) > 0 && short[0] {
+		return l.shortDays[day]
+	}
+	return l.days[day]
+}
+
+// date format a date like [date], but with localized month and weekday names
+// (January, Jan, Monday and Mon in the layout).
+//
+// Example usage: now | localdate "Monday, 2 January 2006".
+func (l localeData) date(layout string, v any) string {
+	t, ok := toTime(v)
+	if !ok {
+		switch v := v.(type) {
+		case int64:
+			t = time.Unix(v, 0)
+		case int:
+			t = time.Unix(int64(v), 0)
+		default:
+			t = time.Now()
+		}
+	}
+	var sb strings.Builder
+	for layout != "" {
+		name, token := "", ""
+		i := 0
+	scan:
+		for ; i < len(layout); i++ {
+			rest := layout[i:]
+			switch {
+			case strings.HasPrefix(rest, "January"):
+				name, token = l.months[t.Month()-1], "January"
+			case strings.HasPrefix(rest, "Jan"):
+				name, token = l.shortMonths[t.Month()-1], "Jan"
+			case strings.HasPrefix(rest, "Monday"):
+				name, token = l.days[t.Weekday()], "Monday"
+			case strings.HasPrefix(rest, "Mon"):
+				name, token = l.shortDays[t.Weekday()], "Mon"
+			default:
+				continue
+			}
+			break scan
+		}
+		sb.WriteString(t.Format(layout[:i]))
+		sb.WriteString(name)
+		layout = layout[min(i+len(token), len(layout)):]
+	}
+	return sb.String()
+}
+
+// formatFloat format the number with the locale separators.
+// The prec is the number of decimals, or -1 for the shortest representation.
+// Halves are rounded away from zero.
+func (l localeData) formatFloat(n float64, prec int) string {
+	if prec >= 0 {
+		pow := math.Pow10(prec)
+		n = math.Round(n*pow) / pow
+	}
+	raw := strconv.FormatFloat(math.Abs(n), 'f', prec, 64)
+	integer, fraction, _ := strings.Cut(raw, ".")
+
+	var sb strings.Builder
+	if n < 0 && strings.Trim(raw, "0.") != "" {
+		sb.WriteByte('-')
+	}
+	for i, c := range integer {
+		if i > 0 && (len(integer)-i)%3 == 0 {
+			sb.WriteString(l.group)
+		}
+		sb.WriteRune(c)
+	}
+	if fraction != "" {
+		sb.WriteString(l.decimal)
+		sb.WriteString(fraction)
+	}
+	return sb.String()
+}
+
+// toTime convert a time or time pointer to [time.Time].
+func toTime(v any) (time.Time, bool) {
+	switch v := v.(type) {
+	case time.Time:
+		return v, true
+	case *time.Time:
+		if v == nil {
+			return time.Time{}, false
+		}
+		return *v, true
+	}
+	return time.Time{}, false
+}
@@ -0,0 +1,112 @@
+package internal
+
+import (
+	"testing"
+	"time"
+)
+
+func TestLocaleNumber(t *testing.T) {
+	en, de, fr := findLocale("en-US"), findLocale("de_DE"), findLocale("fr")
+	if s := en.number(1234567.5); s != "1,234,567.5" {
+		t.Fatalf("en numfmt 1234567.5 = %q", s)
+	}
+	if s := en.number(-1234, 2); s != "-1,234.00" {
+		t.Fatalf("en numfmt -1234 2 = %q", s)
+	}
+	if s := en.number("999"); s != "999" {
+		t.Fatalf("en numfmt \"999\" = %q", s)
+	}
+	if s := de.number(1234.5, 2); s != "1.234,50" {
+		t.Fatalf("de numfmt 1234.5 2 = %q", s)
+	}
+	if s := fr.number(1234.5); s != "1"+narrowNbsp+"234,5" {
+		t.Fatalf("fr numfmt 1234.5 = %q", s)
+	}
+	if s := en.percentage(0.256); s != "26%" {
+		t.Fatalf("en percent 0.256 = %q", s)
+	}
+	if s := de.percentage(0.256, 1); s != "25,6"+nbsp+"%" {
+		t.Fatalf("de percent 0.256 1 = %q", s)
+	}
+	if s := findLocale("xx").number(1000); s != "1,000" {
+		t.Fatalf("unknown locale numfmt 1000 = %q", s)
+	}
+}
+
+func TestLocaleCurrency(t *testing.T) {
+	en, de := findLocale("en"), findLocale("de")
+	if s := en.money(1234.5, "usd"); s != "$1,234.50" {
+		t.Fatalf("en currency USD = %q", s)
+	}
+	if s := en.money(-5, "EUR"); s != "-€5.00" {
+		t.Fatalf("en currency -5 EUR = %q", s)
+	}
+	if s := en.money(1234.5, "JPY"); s != "¥1,235" {
+		t.Fatalf("en currency JPY = %q", s)
+	}
+	if s := de.money(1234.5, "EUR"); s != "1.234,50"+nbsp+"€" {
+		t.Fatalf("de currency EUR = %q", s)
+	}
+	if s := en.money(10, "XYZ"); s != "XYZ10.00" {
+		t.Fatalf("en currency XYZ = %q", s)
+	}
+}
+
+func TestLocaleCompact(t *testing.T) {
+	en, ja := findLocale("en"), findLocale("ja")
+	tests := []struct {
+		l    localeData
+		n    any
+		want string
+	}{
+		{en, 999, "999"},
+		{en, 1234, "1.2K"},
+		{en, 1000, "1K"},
+		{en, 15300, "15K"},
+		{en, 999999, "1M"},
+		{en, -2500000, "-2.5M"},
+		{en, int64(3e12), "3T"},
+		{ja, 12345, "1.2万"},
+		{ja, 1e8, "1億"},
+	}
+	for _, tt := range tests {
+		if s := tt.l.compactNumber(tt.n); s != tt.want {
+			t.Fatalf("compact %v = %q, want %q", tt.n, s, tt.want)
+		}
+	}
+}
+
+func TestLocaleDate(t *testing.T) {
+	date := time.Date(2024, time.March, 4, 10, 0, 0, 0, time.UTC)
+	de, fr := findLocale("de"), findLocale("fr-CA")
+	if s := de.date("Monday, 2. January 2006", date); s != "Montag, 4. März 2024" {
+		t.Fatalf("de localdate = %q", s)
+	}
+	if s := fr.date("Mon 2 Jan 2006 15:04", &date); s != "lun. 4 mars 2024 10:00" {
+		t.Fatalf("fr localdate = %q", s)
+	}
+	if s := de.monthName(12); s != "Dezember" {
+		t.Fatalf("de monthName 12 = %q", s)
+	}
+	if s := fr.monthName(date, true); s != "mars" {
+		t.Fatalf("fr monthName short = %q", s)
+	}
+	if s := de.dayName(0, true); s != "So." {
+		t.Fatalf("de dayName 0 short = %q", s)
+	}
+	if s := de.monthName(13); s != "" {
+		t.Fatalf("de monthName 13 = %q", s)
+	}
+}
+
+func TestResolveLocale(t *testing.T) {
+	tests := map[string]string{
+		"de": "de", "DE": "de", "de-DE": "de", "de_AT": "de", "pt-BR": "pt",
+		"en": "", "en-US": "", "xx": "", "": "", "x-0": "",
+	}
+	for locale, want := range tests {
+		if got := ResolveLocale(locale); got != want {
+			t.Fatalf("resolve %q = %q, want %q", locale, got, want)
+		}
+	}
+}
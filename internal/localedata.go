@@ -0,0 +1,176 @@
+package internal
+
+// localeData is the formatting data of a locale.
+type localeData struct {
+	decimal string
+	group   string
+	// percent the percent pattern, where # is the number.
+	percent string
+	// currency the currency pattern, where # is the amount and ¤ is the currency symbol.
+	currency string
+	// compact the compact number units, in ascending order.
+	compact     []compactUnit
+	months      [12]string
+	shortMonths [12]string
+	// days starting from Sunday, same as [time.Weekday].
+	days      [7]string
+	shortDays [7]string
+}
+
+type compactUnit struct {
+	value  float64
+	suffix string
+}
+
+const (
+	nbsp       = "\u00a0"
+	narrowNbsp = "\u202f"
+)
+
+const defaultLocale = "en"
+
+// locales is the built-in locale data by base language.
+var locales = map[string]localeData{
+	"en": {
+		decimal:     ".",
+		group:       ",",
+		percent:     "#%",
+		currency:    "¤#",
+		compact:     []compactUnit{{1e3, "K"}, {1e6, "M"}, {1e9, "B"}, {1e12, "T"}},
+		months:      [12]string{"January", "February", "March", "April", "May", "June", "July", "August", "September", "October", "November", "December"},
+		shortMonths: [12]string{"Jan", "Feb", "Mar", "Apr", "May", "Jun", "Jul", "Aug", "Sep", "Oct", "Nov", "Dec"},
+		days:        [7]string{"Sunday", "Monday", "Tuesday", "Wednesday", "Thursday", "Friday", "Saturday"},
+		shortDays:   [7]string{"Sun", "Mon", "Tue", "Wed", "Thu", "Fri", "Sat"},
+	},
+	"de": {
+		decimal:     ",",
+		group:       ".",
+		percent:     "#" + nbsp + "%",
+		currency:    "#" + nbsp + "¤",
+		compact:     []compactUnit{{1e3, nbsp + "Tsd."}, {1e6, nbsp + "Mio."}, {1e9, nbsp + "Mrd."}, {1e12, nbsp + "Bio."}},
+		months:      [12]string{"Januar", "Februar", "März", "April", "Mai", "Juni", "Juli", "August", "September", "Oktober", "November", "Dezember"},
+		shortMonths: [12]string{"Jan.", "Feb.", "März", "Apr.", "Mai", "Juni", "Juli", "Aug.", "Sept.", "Okt.", "Nov.", "Dez."},
+		days:        [7]string{"Sonntag", "Montag", "Dienstag", "Mittwoch", "Donnerstag", "Freitag", "Samstag"},
+		shortDays:   [7]string{"So.", "Mo.", "Di.", "Mi.", "Do.", "Fr.", "Sa."},
+	},
+	"fr": {
+		decimal:     ",",
+		group:       narrowNbsp,
+		percent:     "#" + narrowNbsp + "%",
+		currency:    "#" + nbsp + "¤",
+		compact:     []compactUnit{{1e3, nbsp + "k"}, {1e6, nbsp + "M"}, {1e9, nbsp + "Md"}, {1e12, nbsp + "Bn"}},
+		months:      [12]string{"janvier", "février", "mars", "avril", "mai", "juin", "juillet", "août", "septembre", "octobre", "novembre", "décembre"},
+		shortMonths: [12]string{"janv.", "févr.", "mars", "avr.", "mai", "juin", "juil.", "août", "sept.", "oct.", "nov.", "déc."},
+		days:        [7]string{"dimanche", "lundi", "mardi", "mercredi", "jeudi", "vendredi", "samedi"},
+		shortDays:   [7]string{"dim.", "lun.", "mar.", "mer.", "jeu.", "ven.", "sam."},
+	},
+	"es": {
+		decimal:     ",",
+		group:       ".",
+		percent:     "#" + nbsp + "%",
+		currency:    "#" + nbsp + "¤",
+		compact:     []compactUnit{{1e3, nbsp + "mil"}, {1e6, nbsp + "M"}, {1e9, nbsp + "mil" + nbsp + "M"}, {1e12, nbsp + "B"}},
+		months:      [12]string{"enero", "febrero", "marzo", "abril", "mayo", "junio", "julio", "agosto", "septiembre", "octubre", "noviembre", "diciembre"},
+		shortMonths: [12]string{"ene", "feb", "mar", "abr", "may", "jun", "jul", "ago", "sept", "oct", "nov", "dic"},
+		days:        [7]string{"domingo", "lunes", "martes", "miércoles", "jueves", "viernes", "sábado"},
+		shortDays:   [7]string{"dom", "lun", "mar", "mié", "jue", "vie", "sáb"},
+	},
+	"it": {
+		decimal:     ",",
+		group:       ".",
+		percent:     "#%",
+		currency:    "#" + nbsp + "¤",
+		compact:     []compactUnit{{1e6, nbsp + "Mln"}, {1e9, nbsp + "Mrd"}, {1e12, nbsp + "Bln"}},
+		months:      [12]string{"gennaio", "febbraio", "marzo", "aprile", "maggio", "giugno", "luglio", "agosto", "settembre", "ottobre", "novembre", "dicembre"},
+		shortMonths: [12]string{"gen", "feb", "mar", "apr", "mag", "giu", "lug", "ago", "set", "ott", "nov", "dic"},
+		days:        [7]string{"domenica", "lunedì", "martedì", "mercoledì", "giovedì", "venerdì", "sabato"},
+		shortDays:   [7]string{"dom", "lun", "mar", "mer", "gio", "ven", "sab"},
+	},
+	"pt": {
+		decimal:     ",",
+		group:       ".",
+		percent:     "#%",
+		currency:    "¤" + nbsp + "#",
+		compact:     []compactUnit{{1e3, nbsp + "mil"}, {1e6, nbsp + "mi"}, {1e9, nbsp + "bi"}, {1e12, nbsp + "tri"}},
+		months:      [12]string{"janeiro", "fevereiro", "março", "abril", "maio", "junho", "julho", "agosto", "setembro", "outubro", "novembro", "dezembro"},
+		shortMonths: [12]string{"jan.", "fev.", "mar.", "abr.", "mai.", "jun.", "jul.", "ago.", "set.", "out.", "nov.", "dez."},
+		days:        [7]string{"domingo", "segunda-feira", "terça-feira", "quarta-feira", "quinta-feira", "sexta-feira", "sábado"},
+		shortDays:   [7]string{"dom.", "seg.", "ter.", "qua.", "qui.", "sex.", "sáb."},
+	},
+	"nl": {
+		decimal:     ",",
+		group:       ".",
+		percent:     "#%",
+		currency:    "¤" + nbsp + "#",
+		compact:     []compactUnit{{1e3, "K"}, {1e6, nbsp + "mln."}, {1e9, nbsp + "mld."}, {1e12, nbsp + "bln."}},
+		months:      [12]string{"januari", "februari", "maart", "april", "mei", "juni", "juli", "augustus", "september", "oktober", "november", "december"},
+		shortMonths: [12]string{"jan", "feb", "mrt", "apr", "mei", "jun", "jul", "aug", "sep", "okt", "nov", "dec"},
+		days:        [7]string{"zondag", "maandag", "dinsdag", "woensdag", "donderdag", "vrijdag", "zaterdag"},
+		shortDays:   [7]string{"zo", "ma", "di", "wo", "do", "vr", "za"},
+	},
+	"ru": {
+		decimal:     ",",
+		group:       nbsp,
+		percent:     "#" + nbsp + "%",
+		currency:    "#" + nbsp + "¤",
+		compact:     []compactUnit{{1e3, nbsp + "тыс."}, {1e6, nbsp + "млн"}, {1e9, nbsp + "млрд"}, {1e12, nbsp + "трлн"}},
+		months:      [12]string{"января", "февраля", "марта", "апреля", "мая", "июня", "июля", "августа", "сентября", "октября", "ноября", "декабря"},
+		shortMonths: [12]string{"янв.", "февр.", "мар.", "апр.", "мая", "июн.", "июл.", "авг.", "сент.", "окт.", "нояб.", "дек."},
+		days:        [7]string{"воскресенье", "понедельник", "вторник", "среда", "четверг", "пятница", "суббота"},
+		shortDays:   [7]string{"вс", "пн", "вт", "ср", "чт", "пт", "сб"},
+	},
+	"ja": {
+		decimal:     ".",
+		group:       ",",
+		percent:     "#%",
+		currency:    "¤#",
+		compact:     []compactUnit{{1e4, "万"}, {1e8, "億"}, {1e12, "兆"}},
+		months:      [12]string{"1月", "2月", "3月", "4月", "5月", "6月", "7月", "8月", "9月", "10月", "11月", "12月"},
+		shortMonths: [12]string{"1月", "2月", "3月", "4月", "5月", "6月", "7月", "8月", "9月", "10月", "11月", "12月"},
+		days:        [7]string{"日曜日", "月曜日", "火曜日", "水曜日", "木曜日", "金曜日", "土曜日"},
+		shortDays:   [7]string{"日", "月", "火", "水", "木", "金", "土"},
+	},
+	"zh": {
+		decimal:     ".",
+		group:       ",",
+		percent:     "#%",
+		currency:    "¤#",
+		compact:     []compactUnit{{1e4, "万"}, {1e8, "亿"}, {1e12, "万亿"}},
+		months:      [12]string{"一月", "二月", "三月", "四月", "五月", "六月", "七月", "八月", "九月", "十月", "十一月", "十二月"},
+		shortMonths: [12]string{"1月", "2月", "3月", "4月", "5月", "6月", "7月", "8月", "9月", "10月", "11月", "12月"},
+		days:        [7]string{"星期日", "星期一", "星期二", "星期三", "星期四", "星期五", "星期六"},
+		shortDays:   [7]string{"周日", "周一", "周二", "周三", "周四", "周五", "周六"},
+	},
+	"vi": {
+		decimal:     ",",
+		group:       ".",
+		percent:     "#%",
+		currency:    "#" + nbsp + "¤",
+		compact:     []compactUnit{{1e3, nbsp + "N"}, {1e6, nbsp + "Tr"}, {1e9, nbsp + "T"}, {1e12, nbsp + "NT"}},
+		months:      [12]string{"tháng 1", "tháng 2", "tháng 3", "tháng 4", "tháng 5", "tháng 6", "tháng 7", "tháng 8", "tháng 9", "tháng 10", "tháng 11", "tháng 12"},
+		shortMonths: [12]string{"thg 1", "thg 2", "thg 3", "thg 4", "thg 5", "thg 6", "thg 7", "thg 8", "thg 9", "thg 10", "thg 11", "thg 12"},
+		days:        [7]string{"Chủ Nhật", "Thứ Hai", "Thứ Ba", "Thứ Tư", "Thứ Năm", "Thứ Sáu", "Thứ Bảy"},
+		shortDays:   [7]string{"CN", "Th 2", "Th 3", "Th 4", "Th 5", "Th 6", "Th 7"},
+	},
+}
+
+// currencies is the symbol and fraction digits of common ISO 4217 currencies.
+// Other currencies use the code as symbol and 2 fraction digits.
+var currencies = map[string]struct {
+	symbol string
+	digits int
+}{
+	"USD": {"$", 2},
+	"EUR": {"€", 2},
+	"GBP": {"£", 2},
+	"JPY": {"¥", 0},
+	"CNY": {"CN¥", 2},
+	"KRW": {"₩", 0},
+	"INR": {"₹", 2},
+	"RUB": {"₽", 2},
+	"BRL": {"R$", 2},
+	"VND": {"₫", 0},
+	"CAD": {"CA$", 2},
+	"AUD": {"A$", 2},
+	"CHF": {"CHF", 2},
+}
@@ -0,0 +1,94 @@
+package internal
+
+import (
+	"encoding/json"
+	"html/template"
+	"strings"
+)
+
+// NewSafeFuncMap returns the functions for marking trusted content in HTML mode.
+// These functions bypass the html/template escaping, so they must only be used with trusted values.
+func NewSafeFuncMap(excludes ...string) map[string]any {
+	funcs := map[string]any{
+		"safeHTML":   safeHTML,
+		"safeURL":    safeURL,
+		"safeJS":     safeJS,
+		"safeCSS":    safeCSS,
+		"safeAttr":   safeAttr,
+		"toJSON":     toJSONHTML,
+		"jsonScript": jsonScript,
+	}
+	for _, name := range excludes {
+		delete(funcs, name)
+	}
+	return funcs
+}
+
+// safeHTML mark the value as trusted HTML.
+//
+// Example usage: safeHTML .Content.
+func safeHTML(v any) template.HTML {
+	return template.HTML(strval(v))
+}
+
+// safeURL mark the value as trusted URL, allowing schemes such as javascript: and data:.
+//
+// Example usage: <a href="{{ safeURL .Link }}">.
+func safeURL(v any) template.URL {
+	return template.URL(strval(v))
+}
+
+// safeJS mark the value as trusted JavaScript expression.
+//
+// Example usage: <script>{{ safeJS .Script }}</script>.
+func safeJS(v any) template.JS {
+	return template.JS(strval(v))
+}
+
+// safeCSS mark the value as trusted CSS.
+//
+// Example usage: <div style="{{ safeCSS .Style }}">.
+func safeCSS(v any) template.CSS {
+	return template.CSS(strval(v))
+}
+
+// safeAttr mark the value as trusted HTML attribute, such as `dir="ltr"`.
+//
+// Example usage: <p {{ safeAttr .Attr }}>.
+func safeAttr(v any) template.HTMLAttr {
+	return template.HTMLAttr(strval(v))
+}
+
+// toJSONHTML encode the value as JSON, usable as JavaScript value in script.
+// The characters <, > and & are escaped, so the JSON cannot close the script element.
+//
+// Example usage: <script>const data = {{ toJSON .Data }};</script>.
+func toJSONHTML(v any) (template.JS, error) {
+	b, err := json.Marshal(v)
+	if err != nil {
+		return "", err
+	}
+	return template.JS(b), nil
+}
+
+// jsonScript encode the value as JSON inside a <script type="application/json"> element with the id,
+// which can be read using JSON.parse(document.getElementById(id).textContent).
+//
+// Example usage: jsonScript "page-data" .Data.
+func jsonScript(id string, v any) (template.HTML, error) {
+	b, err := json.Marshal(v)
+	if err != nil {
+		return "", err
+	}
+	var sb strings.Builder
+	sb.WriteString(`<script type="application/json"`)
+	if id != "" {
+		sb.WriteString(` id="`)
+		sb.WriteString(template.HTMLEscapeString(id))
+		sb.WriteString(`"`)
+	}
+	sb.WriteString(">")
+	sb.Write(b)
+	sb.WriteString("</script>")
+	return template.HTML(sb.String()), nil
+}
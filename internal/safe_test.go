@@ -0,0 +1,46 @@
+package internal
+
+import (
+	"html/template"
+	"strings"
+	"testing"
+)
+
+func TestSafeFuncs(t *testing.T) {
+	tests := []struct {
+		text string
+		data any
+		want string
+	}{
+		{`{{ safeHTML . }}`, "<b>x</b>", "<b>x</b>"},
+		{`{{ . }}`, "<b>x</b>", "&lt;b&gt;x&lt;/b&gt;"},
+		{`<a href="{{ safeURL . }}">`, "javascript:alert`x`", "<a href=\"javascript:alert%60x%60\">"},
+		{`<a href="{{ . }}">`, "javascript:void(0)", `<a href="#ZgotmplZ">`},
+		{`<script>{{ safeJS . }}</script>`, "alert(1)", "<script>alert(1)</script>"},
+		{`<div style="{{ safeCSS . }}">`, "color: red", `<div style="color: red">`},
+		{`<p {{ safeAttr . }}>`, `dir="rtl"`, `<p dir="rtl">`},
+		{`<script>const d = {{ toJSON . }};</script>`, map[string]any{"a": "</script>"},
+			`<script>const d = {"a":"\u003c/script\u003e"};</script>`},
+		{`{{ jsonScript "data" . }}`, []any{1, "<&>"},
+			`<script type="application/json" id="data">[1,"\u003c\u0026\u003e"]</script>`},
+		{`{{ jsonScript "a\"b" . }}`, nil, `<script type="application/json" id="a&#34;b">null</script>`},
+	}
+	for _, tt := range tests {
+		tmpl := template.Must(template.New("test").Funcs(NewSafeFuncMap()).Parse(tt.text))
+		var sb strings.Builder
+		if err := tmpl.Execute(&sb, tt.data); err != nil {
+			t.Fatalf("execute %s: %v", tt.text, err)
+		}
+		if sb.String() != tt.want {
+			t.Fatalf("execute %s = %q, want %q", tt.text, sb.String(), tt.want)
+		}
+	}
+
+	tmpl := template.Must(template.New("test").Funcs(NewSafeFuncMap()).Parse(`{{ toJSON . }}`))
+	if err := tmpl.Execute(&strings.Builder{}, make(chan int)); err == nil {
+		t.Fatalf("toJSON of channel must fail")
+	}
+	if funcs := NewSafeFuncMap("safeHTML"); funcs["safeHTML"] != nil {
+		t.Fatalf("excluded safe funcs are registered")
+	}
+}
@@ -0,0 +1,320 @@
+package internal
+
+import (
+	"html/template"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// NewHTMLFuncMap returns the functions that replace the builtins in HTML mode.
+func NewHTMLFuncMap(excludes ...string) map[string]any {
+	funcs := map[string]any{
+		"nl2br": nl2brHTML,
+	}
+	for _, name := range excludes {
+		delete(funcs, name)
+	}
+	return funcs
+}
+
+// trim remove leading and trailing white spaces.
+//
+// Example usage: trim "  hello  " => hello.
+func trim(v any) string {
+	return strings.TrimSpace(strval(v))
+}
+
+// replace replace all occurrences of from by to.
+//
+// Example usage: "hello world" | replace " " "-" => hello-world.
+func replace(from, to string, v any) string {
+	return strings.ReplaceAll(strval(v), from, to)
+}
+
+// split split the string by the separator.
+//
+// Example usage: split "," "a,b,c".
+func split(sep string, v any) []string {
+	return strings.Split(strval(v), sep)
+}
+
+// join join the items of the list using the separator.
+//
+// Example usage: join ", " .Tags.
+func join(sep string, l any) (string, error) {
+	if s, ok := l.([]string); ok {
+		return strings.Join(s, sep), nil
+	}
+	items, err := toList("join", l)
+	if err != nil {
+		return "", err
+	}
+	res := make([]string, 0, len(items))
+	for _, item := range items {
+		if item != nil {
+			res = append(res, strval(item))
+		}
+	}
+	return strings.Join(res, sep), nil
+}
+
+// contains return whether the string contains the substring.
+//
+// Example usage: .Name | contains "admin".
+func contains(substr string, v any) bool {
+	return strings.Contains(strval(v), substr)
+}
+
+// hasPrefix return whether the string starts with the prefix.
+//
+// Example usage: .URL | hasPrefix "https://".
+func hasPrefix(prefix string, v any) bool {
+	return strings.HasPrefix(strval(v), prefix)
+}
+
+// hasSuffix return whether the string ends with the suffix.
+//
+// Example usage: .File | hasSuffix ".pdf".
+func hasSuffix(suffix string, v any) bool {
+	return strings.HasSuffix(strval(v), suffix)
+}
+
+// repeat repeat the string count times.
+//
+// Example usage: repeat 3 "ab" => ababab.
+func repeat(count any, v any) string {
+	return strings.Repeat(strval(v), max(toInt(count), 0))
+}
+
+// substr return the characters (not bytes) from start (inclusive) to end (exclusive).
+// A negative end means to the end of the string. Out of range indices are clamped.
+//
+// Example usage: substr 0 5 "hello world" => hello.
+func substr(start, end any, v any) string {
+	runes := []rune(strval(v))
+	s, e := toInt(start), toInt(end)
+	if e < 0 || e > len(runes) {
+		e = len(runes)
+	}
+	s = min(max(s, 0), e)
+	return string(runes[s:e])
+}
+
+// abbrev shorten the string to at most width characters, using "..." as the ending.
+//
+// Example usage: abbrev 8 "hello world" => hello....
+func abbrev(width any, v any) string {
+	s := strval(v)
+	w := toInt(width)
+	if w < 0 || utf8.RuneCountInString(s) <= w {
+		return s
+	}
+	runes := []rune(s)
+	if w <= 3 {
+		return string(runes[:w])
+	}
+	return string(runes[:w-3]) + "..."
+}
+
+// wrap wrap the text into lines of at most width characters, breaking at spaces.
+// Words longer than the width are not broken.
+//
+// Example usage: wrap 10 .Text.
+func wrap(width any, v any) string {
+	w := toInt(width)
+	var sb strings.Builder
+	for i, line := range strings.Split(strval(v), "\n") {
+		if i > 0 {
+			sb.WriteByte('\n')
+		}
+		n := 0
+		for _, word := range strings.Fields(line) {
+			size := utf8.RuneCountInString(word)
+			switch {
+			case n == 0:
+			case n+1+size > w:
+				sb.WriteByte('\n')
+				n = 0
+			default:
+				sb.WriteByte(' ')
+				n++
+			}
+			sb.WriteString(word)
+			n += size
+		}
+	}
+	return sb.String()
+}
+
+// slugify convert the string to a URL friendly slug, removing accents from latin letters.
+//
+// Example usage: slugify "Xin chào, Thế giới!" => xin-chao-the-gioi.
+func slugify(v any) string {
+	var sb strings.Builder
+	dash := false
+	for _, r := range strings.ToLower(strval(v)) {
+		if folded, ok := accents[r]; ok {
+			r = folded
+		}
+		if unicode.IsLetter(r) || unicode.IsDigit(r) {
+			if dash && sb.Len() > 0 {
+				sb.WriteByte('-')
+			}
+			dash = false
+			if r == 'ß' {
+				sb.WriteString("ss")
+				continue
+			}
+			sb.WriteRune(r)
+			continue
+		}
+		if !unicode.Is(unicode.Mn, r) {
+			dash = true
+		}
+	}
+	return sb.String()
+}
+
+// camelcase convert the string to lower camel case.
+//
+// Example usage: camelcase "http_server url" => httpServerUrl.
+func camelcase(v any) string {
+	var sb strings.Builder
+	for i, word := range splitWords(strval(v)) {
+		word = strings.ToLower(word)
+		if i > 0 {
+			word = upperFirst(word)
+		}
+		sb.WriteString(word)
+	}
+	return sb.String()
+}
+
+// snakecase convert the string to snake case.
+//
+// Example usage: snakecase "HTTPServer URL" => http_server_url.
+func snakecase(v any) string {
+	return strings.ToLower(strings.Join(splitWords(strval(v)), "_"))
+}
+
+// kebabcase convert the string to kebab case.
+//
+// Example usage: kebabcase "HTTPServer URL" => http-server-url.
+func kebabcase(v any) string {
+	return strings.ToLower(strings.Join(splitWords(strval(v)), "-"))
+}
+
+// capitalize uppercase the first letter of each word.
+//
+// Example usage: capitalize "hello world" => Hello World.
+func capitalize(v any) string {
+	runes := []rune(strval(v))
+	for i, r := range runes {
+		if i == 0 || unicode.IsSpace(runes[i-1]) {
+			runes[i] = unicode.ToTitle(r)
+		}
+	}
+	return string(runes)
+}
+
+// initials return the uppercase first letter of each word.
+//
+// Example usage: initials "John Ronald Tolkien" => JRT.
+func initials(v any) string {
+	var sb strings.Builder
+	for _, word := range strings.Fields(strval(v)) {
+		r, _ := utf8.DecodeRuneInString(word)
+		sb.WriteRune(unicode.ToUpper(r))
+	}
+	return sb.String()
+}
+
+// nl2br insert <br> before each newline.
+// In text mode the string is not escaped.
+//
+// Example usage: nl2br .Address.
+func nl2br(v any) string {
+	return strings.ReplaceAll(strings.ReplaceAll(strval(v), "\r\n", "\n"), "\n", "<br>\n")
+}
+
+// nl2brHTML is [nl2br] of HTML mode, which escapes the string unless it is [template.HTML].
+func nl2brHTML(v any) template.HTML {
+	s, ok := v.(template.HTML)
+	if !ok {
+		s = template.HTML(template.HTMLEscapeString(strval(v)))
+	}
+	return template.HTML(nl2br(string(s)))
+}
+
+// splitWords split the string into words at non-alphanumeric characters and case changes.
+// Uppercase sequences are kept as a word, such as "HTTP" and "Server" of "HTTPServer".
+func splitWords(s string) []string {
+	runes := []rune(s)
+	words := make([]string, 0, 4)
+	start := -1
+	for i, r := range runes {
+		if !unicode.IsLetter(r) && !unicode.IsDigit(r) {
+			if start >= 0 {
+				words = append(words, string(runes[start:i]))
+				start = -1
+			}
+			continue
+		}
+		if start < 0 {
+			start = i
+			continue
+		}
+		prev := runes[i-1]
+		lowerToUpper := unicode.IsUpper(r) && !unicode.IsUpper(prev)
+		acronymEnd := unicode.IsUpper(r) && unicode.IsUpper(prev) && i+1 < len(runes) && unicode.IsLower(runes[i+1])
+		if lowerToUpper || acronymEnd {
+			words = append(words, string(runes[start:i]))
+			start = i
+		}
+	}
+	if start >= 0 {
+		words = append(words, string(runes[start:]))
+	}
+	return words
+}
+
+func upperFirst(s string) string {
+	r, size := utf8.DecodeRuneInString(s)
+	if size == 0 {
+		return s
+	}
+	return string(unicode.ToUpper(r)) + s[size:]
+}
+
+// accents map accented latin letters (lowercase) to their base letters.
+var accents = func() map[rune]rune {
+	bases := map[rune]string{
+		'a': "àáâãäåāăąạảấầẩẫậắằẳẵặǎ",
+		'c': "çćĉċč",
+		'd': "ďđ",
+		'e': "èéêëēĕėęěẹẻẽếềểễệ",
+		'g': "ĝğġģ",
+		'h': "ĥħ",
+		'i': "ìíîïĩīĭįıỉịǐ",
+		'j': "ĵ",
+		'k': "ķ",
+		'l': "ĺļľŀł",
+		'n': "ñńņňŉ",
+		'o': "òóôõöøōŏőơọỏốồổỗộớờởỡợǒ",
+		'r': "ŕŗř",
+		's': "śŝşšș",
+		't': "ţťŧț",
+		'u': "ùúûüũūŭůűųưụủứừửữựǔ",
+		'w': "ŵ",
+		'y': "ýÿŷỳỵỷỹ",
+		'z': "źżž",
+	}
+	res := make(map[rune]rune, 200)
+	for base, chars := range bases {
+		for _, r := range chars {
+			res[r] = base
+		}
+	}
+	return res
+}()
@@ -0,0 +1,94 @@
+package internal
+
+import (
+	"html/template"
+	"reflect"
+	"strings"
+	"testing"
+	texttemplate "text/template"
+)
+
+func TestStringFuncs(t *testing.T) {
+	tests := []struct {
+		name string
+		got  any
+		want any
+	}{
+		{"trim", trim("  a b \n"), "a b"},
+		{"replace", replace(" ", "-", "a b c"), "a-b-c"},
+		{"split", split(",", "a,b,,c"), []string{"a", "b", "", "c"}},
+		{"contains", contains("ell", "hello"), true},
+		{"hasPrefix", hasPrefix("https://", "http://x"), false},
+		{"hasSuffix", hasSuffix(".pdf", "a.pdf"), true},
+		{"repeat", repeat(3, "ab"), "ababab"},
+		{"repeat negative", repeat(-1, "ab"), ""},
+		{"substr", substr(0, 5, "hello world"), "hello"},
+		{"substr runes", substr(1, 3, "héllo"), "él"},
+		{"substr to end", substr(6, -1, "hello world"), "world"},
+		{"substr clamp", substr(8, 4, "hello"), ""},
+		{"abbrev", abbrev(8, "hello world"), "hello..."},
+		{"abbrev short", abbrev(20, "hello"), "hello"},
+		{"abbrev tiny", abbrev(2, "hello"), "he"},
+		{"wrap", wrap(10, "the quick brown fox jumps"), "the quick\nbrown fox\njumps"},
+		{"wrap long word", wrap(3, "abcdef gh"), "abcdef\ngh"},
+		{"wrap lines", wrap(5, "ab cd ef\ngh"), "ab cd\nef\ngh"},
+		{"slugify", slugify("Xin chào, Thế giới!"), "xin-chao-the-gioi"},
+		{"slugify latin", slugify("  Crème Brûlée -- Straße 2024 "), "creme-brulee-strasse-2024"},
+		{"slugify unicode", slugify("Привет мир"), "привет-мир"},
+		{"camelcase", camelcase("http_server url"), "httpServerUrl"},
+		{"camelcase acronym", camelcase("HTTPServer"), "httpServer"},
+		{"snakecase", snakecase("HTTPServer URL"), "http_server_url"},
+		{"snakecase camel", snakecase("userID2fa"), "user_id2fa"},
+		{"kebabcase", kebabcase("HelloWorld foo_bar"), "hello-world-foo-bar"},
+		{"capitalize", capitalize("hello wORLD  élan"), "Hello WORLD  Élan"},
+		{"initials", initials("john ronald  reuel tolkien"), "JRRT"},
+		{"nl2br", nl2br("a\r\nb\nc"), "a<br>\nb<br>\nc"},
+		{"nl2br html", nl2brHTML("<b>\nc"), template.HTML("&lt;b&gt;<br>\nc")},
+		{"nl2br html trusted", nl2brHTML(template.HTML("<b>\nc")), template.HTML("<b><br>\nc")},
+	}
+	for _, tt := range tests {
+		if !reflect.DeepEqual(tt.got, tt.want) {
+			t.Fatalf("%s = %#v, want %#v", tt.name, tt.got, tt.want)
+		}
+	}
+
+	if s, err := join(", ", []string{"a", "b"}); err != nil || s != "a, b" {
+		t.Fatalf("join []string = %q, %v", s, err)
+	}
+	if s, err := join("-", []any{1, nil, "x"}); err != nil || s != "1-x" {
+		t.Fatalf("join []any = %q, %v", s, err)
+	}
+	if _, err := join(",", 1); err == nil {
+		t.Fatalf("join int must fail")
+	}
+}
+
+func TestStringFuncsTemplate(t *testing.T) {
+	const text = `{{ nl2br .Text }}|{{ .Names | join ", " }}|{{ .Text | replace "\n" " " | upper }}`
+	data := map[string]any{"Text": "<a>\nb", "Names": []string{"x", "y"}}
+
+	var sb strings.Builder
+	html := template.Must(template.New("html").Funcs(NewBuiltinFuncMap()).Funcs(NewHTMLFuncMap()).Parse(text))
+	if err := html.Execute(&sb, data); err != nil {
+		t.Fatalf("execute html: %v", err)
+	}
+	if want := "&lt;a&gt;<br>\nb|x, y|&lt;A&gt; B"; sb.String() != want {
+		t.Fatalf("html = %q, want %q", sb.String(), want)
+	}
+
+	sb.Reset()
+	txt := texttemplate.Must(texttemplate.New("text").Funcs(NewBuiltinFuncMap()).Parse(text))
+	if err := txt.Execute(&sb, data); err != nil {
+		t.Fatalf("execute text: %v", err)
+	}
+	if want := "<a><br>\nb|x, y|<A> B"; sb.String() != want {
+		t.Fatalf("text = %q, want %q", sb.String(), want)
+	}
+
+	if funcs := NewBuiltinFuncMap("slugify", "nl2br"); funcs["slugify"] != nil || funcs["nl2br"] != nil {
+		t.Fatalf("excluded funcs are registered")
+	}
+	if funcs := NewHTMLFuncMap("nl2br"); funcs["nl2br"] != nil {
+		t.Fatalf("excluded html funcs are registered")
+	}
+}
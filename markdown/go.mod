@@ -0,0 +1,11 @@
+module github.com/mawngo/go-tmpls/v2/markdown
+
+go 1.25
+
+require (
+	github.com/mawngo/go-tmpls/v2 v2.0.0
+	github.com/yuin/goldmark v1.8.6
+	golang.org/x/net v0.50.0
+)
+
+replace github.com/mawngo/go-tmpls/v2 => ../
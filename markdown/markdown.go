@@ -0,0 +1,184 @@
+// Package markdown provides the markdown template function, rendering Markdown to sanitized HTML.
+package markdown
+
+import (
+	"bytes"
+	"container/list"
+	"crypto/sha256"
+	"fmt"
+	"github.com/mawngo/go-tmpls/v2"
+	"github.com/yuin/goldmark"
+	"github.com/yuin/goldmark/extension"
+	"github.com/yuin/goldmark/renderer/html"
+	"html/template"
+	"sync"
+)
+
+// DefaultCacheSize is the default number of rendered documents kept in the cache.
+const DefaultCacheSize = 256
+
+// Renderer render Markdown to sanitized HTML.
+// The rendered HTML is cached by the hash of the source, so repeated renders are cheap.
+type Renderer struct {
+	md        goldmark.Markdown
+	unsafe    bool
+	policy    Policy
+	sanitizer *sanitizer
+	cacheSize int
+
+	mu    sync.Mutex
+	cache map[[sha256.Size]byte]*list.Element
+	lru   *list.List
+}
+
+type cacheEntry struct {
+	key  [sha256.Size]byte
+	html template.HTML
+}
+
+// Option is the option for configuring [Renderer].
+type Option func(*Renderer)
+
+// WithGoldmark set the goldmark instance used for parsing,
+// replacing the default one with GitHub Flavored Markdown extensions.
+// The output is always sanitized.
+func WithGoldmark(md goldmark.Markdown) Option {
+	return func(r *Renderer) {
+		r.md = md
+	}
+}
+
+// WithUnsafeHTML keep the raw HTML of the Markdown source, instead of omitting it.
+// The raw HTML is still sanitized using the policy.
+// Has no effect when used with [WithGoldmark].
+func WithUnsafeHTML() Option {
+	return func(r *Renderer) {
+		r.unsafe = true
+	}
+}
+
+// WithPolicy set the sanitizer policy, replacing the [DefaultPolicy].
+func WithPolicy(policy Policy) Option {
+	return func(r *Renderer) {
+		r.policy = policy
+	}
+}
+
+// WithCacheSize set the number of rendered documents kept in the cache.
+// Zero or negative disables the cache.
+func WithCacheSize(size int) Option {
+	return func(r *Renderer) {
+		r.cacheSize = size
+	}
+}
+
+// New create a new [Renderer].
+func New(options ...Option) *Renderer {
+	r := &Renderer{
+		policy:    DefaultPolicy(),
+		cacheSize: DefaultCacheSize,
+	}
+	for _, option := range options {
+		option(r)
+	}
+	if r.md == nil {
+		var rendererOptions []goldmark.Option
+		if r.unsafe {
+			rendererOptions = append(rendererOptions, goldmark.WithRendererOptions(html.WithUnsafe()))
+		}
+		r.md = goldmark.New(append(rendererOptions, goldmark.WithExtensions(extension.GFM))...)
+	}
+	r.sanitizer = newSanitizer(r.policy)
+	if r.cacheSize > 0 {
+		r.cache = make(map[[sha256.Size]byte]*list.Element, r.cacheSize)
+		r.lru = list.New()
+	}
+	return r
+}
+
+// Render convert the Markdown source to sanitized HTML.
+// The source can be a string, []byte, [template.HTML] or [fmt.Stringer]. Nil renders nothing.
+func (r *Renderer) Render(src any) (template.HTML, error) {
+	var source []byte
+	switch v := src.(type) {
+	case nil:
+		return "", nil
+	case string:
+		source = []byte(v)
+	case []byte:
+		source = v
+	case template.HTML:
+		source = []byte(v)
+	case fmt.Stringer:
+		source = []byte(v.String())
+	default:
+		return "", fmt.Errorf("markdown: expected string, got %T", src)
+	}
+	if len(source) == 0 {
+		return "", nil
+	}
+
+	if r.cache == nil {
+		return r.render(source)
+	}
+	key := sha256.Sum256(source)
+	if res, ok := r.cached(key); ok {
+		return res, nil
+	}
+	res, err := r.render(source)
+	if err != nil {
+		return "", err
+	}
+	r.store(key, res)
+	return res, nil
+}
+
+func (r *Renderer) render(source []byte) (template.HTML, error) {
+	var buf bytes.Buffer
+	if err := r.md.Convert(source, &buf); err != nil {
+		return "", fmt.Errorf("markdown: %w", err)
+	}
+	return template.HTML(r.sanitizer.sanitize(buf.String())), nil
+}
+
+func (r *Renderer) cached(key [sha256.Size]byte) (template.HTML, bool) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	elem, ok := r.cache[key]
+	if !ok {
+		return "", false
+	}
+	r.lru.MoveToFront(elem)
+	return elem.Value.(*cacheEntry).html, true
+}
+
+func (r *Renderer) store(key [sha256.Size]byte, res template.HTML) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if elem, ok := r.cache[key]; ok {
+		r.lru.MoveToFront(elem)
+		return
+	}
+	r.cache[key] = r.lru.PushFront(&cacheEntry{key: key, html: res})
+	for r.lru.Len() > r.cacheSize {
+		oldest := r.lru.Back()
+		r.lru.Remove(oldest)
+		delete(r.cache, oldest.Value.(*cacheEntry).key)
+	}
+}
+
+// Funcs returns the markdown template function.
+//
+// Example usage: {{ markdown .Body }}.
+func (r *Renderer) Funcs() tmpls.FuncMap {
+	return tmpls.FuncMap{
+		"markdown": r.Render,
+	}
+}
+
+// Option returns the [tmpls.TemplatesOption] that sets the markdown template function using [tmpls.WithFuncs].
+// As WithFuncs replaces the previously set functions, add [Renderer.Funcs] to your own map instead when
+// using WithFuncs for other functions.
+func (r *Renderer) Option() tmpls.TemplatesOption {
+	return tmpls.WithFuncs(r.Funcs())
+}
@@ -0,0 +1,94 @@
+package markdown
+
+import (
+	"crypto/sha256"
+	"github.com/mawngo/go-tmpls/v2"
+	"html/template"
+	"strings"
+	"testing"
+	"testing/fstest"
+)
+
+func TestRender(t *testing.T) {
+	r := New()
+	tests := []struct {
+		src  any
+		want string
+	}{
+		{"# Hello *World*", `<h1 id="user-content-hello-world">Hello <em>World</em></h1>`},
+		{"# config", `<h1 id="user-content-config">config</h1>`},
+		{"~~old~~ https://example.com", `<p><del>old</del> <a href="https://example.com">https://example.com</a></p>`},
+		{"- [x] done", `<li><input checked="" disabled="" type="checkbox"> done</li>`},
+		{"| a |\n|--:|\n| 1 |", `<td style="text-align:right">1</td>`},
+		{"[x](javascript:alert(1))", `<p><a href="">x</a></p>`},
+		{"hi <script>alert(1)</script>", `<p>hi alert(1)</p>`},
+		{[]byte("**bold**"), `<p><strong>bold</strong></p>`},
+		{template.HTML("`<b>`"), `<p><code>&lt;b&gt;</code></p>`},
+		{nil, ``},
+	}
+	for _, test := range tests {
+		got, err := r.Render(test.src)
+		if err != nil {
+			t.Fatalf("render %q: %v", test.src, err)
+		}
+		if !strings.Contains(string(got), test.want) {
+			t.Fatalf("render %q = %q, want %q", test.src, got, test.want)
+		}
+	}
+	if _, err := r.Render(1); err == nil {
+		t.Fatalf("render int must fail")
+	}
+}
+
+func TestRenderUnsafeHTML(t *testing.T) {
+	r := New(WithUnsafeHTML())
+	got, err := r.Render("<div class=\"note\" onclick=\"x()\">note</div>\n\n<script>alert(1)</script>\n\n<img src=x onerror=alert(1)>")
+	if err != nil {
+		t.Fatalf("render: %v", err)
+	}
+	want := "<div class=\"note\">note</div>\n\n<img src=\"x\">"
+	if string(got) != want {
+		t.Fatalf("render = %q, want %q", got, want)
+	}
+}
+
+func TestRenderCache(t *testing.T) {
+	r := New(WithCacheSize(2))
+	for _, src := range []string{"a", "b", "a", "c"} {
+		if _, err := r.Render(src); err != nil {
+			t.Fatalf("render: %v", err)
+		}
+	}
+	if r.lru.Len() != 2 {
+		t.Fatalf("cache size = %d, want 2", r.lru.Len())
+	}
+	if _, ok := r.cached(sha256.Sum256([]byte("b"))); ok {
+		t.Fatalf("least recently used entry must be evicted")
+	}
+	if _, ok := r.cached(sha256.Sum256([]byte("a"))); !ok {
+		t.Fatalf("recently used entry must be kept")
+	}
+
+	r = New(WithCacheSize(0))
+	if _, err := r.Render("a"); err != nil || r.cache != nil {
+		t.Fatalf("cache must be disabled")
+	}
+}
+
+func TestRendererTemplates(t *testing.T) {
+	fsys := fstest.MapFS{
+		"index.gohtml": {Data: []byte(`<article>{{ markdown .Body }}</article>`)},
+	}
+	templates, err := tmpls.New(fsys, New().Option())
+	if err != nil {
+		t.Fatalf("new templates: %v", err)
+	}
+	var sb strings.Builder
+	if err := templates.ExecuteTemplate(&sb, "index", map[string]any{"Body": "## Title\n\n<b>x</b>"}); err != nil {
+		t.Fatalf("execute: %v", err)
+	}
+	want := "<article><h2 id=\"user-content-title\">Title</h2>\n<p>x</p>\n</article>"
+	if got := sb.String(); got != want {
+		t.Fatalf("execute = %q, want %q", got, want)
+	}
+}
@@ -0,0 +1,282 @@
+package markdown
+
+import (
+	"golang.org/x/net/html"
+	"net/url"
+	"regexp"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+// Policy is the allowlist of HTML elements and attributes kept by the sanitizer.
+// Elements that are not allowed are removed, keeping their text content,
+// except elements in [Policy.DropContent] which are removed with their content.
+type Policy struct {
+	// Elements allowed element names, with their allowed attribute names.
+	// Allowed ids are suffixed by a number when already used in the document, like the generated heading ids.
+	Elements map[string][]string
+	// URLSchemes allowed schemes of href and src attributes. Relative URLs are always allowed.
+	URLSchemes []string
+	// DropContent elements removed with their content.
+	DropContent []string
+	// IDPrefix prefix of the generated heading ids and the allowed ids,
+	// so they cannot clobber the globals of the page scripts (such as a heading "location").
+	IDPrefix string
+}
+
+// DefaultPolicy returns the policy allowing the elements generated from Markdown (including GFM extensions).
+// The id attribute is not allowed in raw HTML, and the ids generated from the heading text are prefixed
+// by "user-content-" (like GitHub), so they cannot clobber the globals of the page scripts.
+func DefaultPolicy() Policy {
+	return Policy{
+		Elements: map[string][]string{
+			"p": nil, "br": nil, "hr": nil, "blockquote": nil, "pre": nil, "code": {"class"},
+			"h1": nil, "h2": nil, "h3": nil, "h4": nil, "h5": nil, "h6": nil,
+			"em": nil, "strong": nil, "del": nil, "s": nil, "sup": nil, "sub": nil, "kbd": nil, "mark": nil,
+			"ul": nil, "ol": {"start"}, "li": nil, "dl": nil, "dt": nil, "dd": nil,
+			"a": {"href", "title"}, "img": {"src", "alt", "title", "width", "height"},
+			"table": nil, "thead": nil, "tbody": nil, "tr": nil, "th": {"align", "style"}, "td": {"align", "style"},
+			"input": {"type", "checked", "disabled"}, "section": {"class"}, "div": {"class"}, "span": nil,
+		},
+		URLSchemes:  []string{"http", "https", "mailto"},
+		DropContent: []string{"script", "style", "iframe", "object", "embed", "textarea", "title", "noscript", "template"},
+		IDPrefix:    "user-content-",
+	}
+}
+
+var (
+	// alignStyleRegex the only allowed style, which is generated for aligned table cells.
+	alignStyleRegex = regexp.MustCompile(`^text-align:\s*(left|right|center);?$`)
+	// classRegex allowed classes, such as the language of code blocks and footnotes.
+	classRegex = regexp.MustCompile(`^[\w-]+( [\w-]+)*$`)
+)
+
+// sanitizer remove the elements and attributes not allowed by the policy,
+// and add id to headings without one.
+type sanitizer struct {
+	elements    map[string]map[string]struct{}
+	schemes     map[string]struct{}
+	dropContent map[string]struct{}
+	idPrefix    string
+}
+
+func newSanitizer(p Policy) *sanitizer {
+	s := &sanitizer{
+		elements:    make(map[string]map[string]struct{}, len(p.Elements)),
+		schemes:     make(map[string]struct{}, len(p.URLSchemes)),
+		dropContent: make(map[string]struct{}, len(p.DropContent)),
+		idPrefix:    p.IDPrefix,
+	}
+	for name, attrs := range p.Elements {
+		allowed := make(map[string]struct{}, len(attrs))
+		for _, attr := range attrs {
+			allowed[attr] = struct{}{}
+		}
+		s.elements[name] = allowed
+	}
+	for _, scheme := range p.URLSchemes {
+		s.schemes[strings.ToLower(scheme)] = struct{}{}
+	}
+	for _, name := range p.DropContent {
+		s.dropContent[name] = struct{}{}
+	}
+	return s
+}
+
+// sanitize returns the sanitized HTML.
+func (s *sanitizer) sanitize(src string) string {
+	var sb strings.Builder
+	ids := make(map[string]int)
+	tokenizer := html.NewTokenizer(strings.NewReader(src))
+	// Name of the element whose content is being dropped.
+	dropping := ""
+	depth := 0
+	// Buffered heading without id, written when the heading ends.
+	var heading *html.Token
+	var headingBuf strings.Builder
+	var headingText strings.Builder
+
+	out := &sb
+	for {
+		tt := tokenizer.Next()
+		if tt == html.ErrorToken {
+			// io.EOF or a malformed input, the content written so far is kept.
+			break
+		}
+		token := tokenizer.Token()
+
+		if dropping != "" {
+			switch {
+			case tt == html.StartTagToken && token.Data == dropping:
+				depth++
+			case tt == html.EndTagToken && token.Data == dropping:
+				depth--
+				if depth == 0 {
+					dropping = ""
+				}
+			}
+			continue
+		}
+
+		switch tt {
+		case html.TextToken:
+			out.WriteString(html.EscapeString(token.Data))
+			if heading != nil {
+				headingText.WriteString(token.Data)
+			}
+		case html.StartTagToken, html.SelfClosingTagToken:
+			if _, ok := s.dropContent[token.Data]; ok {
+				if tt == html.StartTagToken {
+					dropping, depth = token.Data, 1
+				}
+				continue
+			}
+			allowed, ok := s.elements[token.Data]
+			if !ok {
+				continue
+			}
+			token.Attr = s.attributes(token.Data, token.Attr, allowed)
+			token.Attr = s.uniqueIDs(ids, token.Attr)
+			if tt == html.StartTagToken && heading == nil && isHeading(token.Data) && !hasAttr(token.Attr, "id") {
+				heading = &token
+				out = &headingBuf
+				continue
+			}
+			out.WriteString(token.String())
+		case html.EndTagToken:
+			if _, ok := s.elements[token.Data]; !ok {
+				continue
+			}
+			if heading != nil && token.Data == heading.Data {
+				if slug := slugify(headingText.String()); slug != "" {
+					id := uniqueID(ids, s.idPrefix+slug)
+					heading.Attr = append(heading.Attr, html.Attribute{Key: "id", Val: id})
+				}
+				sb.WriteString(heading.String())
+				sb.WriteString(headingBuf.String())
+				heading = nil
+				headingBuf.Reset()
+				headingText.Reset()
+				out = &sb
+			}
+			out.WriteString(token.String())
+		}
+	}
+	if heading != nil {
+		sb.WriteString(heading.String())
+		sb.WriteString(headingBuf.String())
+	}
+	return sb.String()
+}
+
+// attributes returns the allowed attributes of the element.
+func (s *sanitizer) attributes(element string, attrs []html.Attribute, allowed map[string]struct{}) []html.Attribute {
+	res := make([]html.Attribute, 0, len(attrs))
+	for _, a := range attrs {
+		if a.Namespace != "" {
+			continue
+		}
+		if _, ok := allowed[a.Key]; !ok {
+			continue
+		}
+		switch a.Key {
+		case "href", "src":
+			if !s.allowURL(a.Val) {
+				continue
+			}
+		case "style":
+			if !alignStyleRegex.MatchString(strings.TrimSpace(a.Val)) {
+				continue
+			}
+		case "class":
+			if !classRegex.MatchString(a.Val) {
+				continue
+			}
+		case "type":
+			// Only checkbox of task lists is allowed.
+			if element == "input" && a.Val != "checkbox" {
+				return nil
+			}
+		}
+		res = append(res, a)
+	}
+	if element == "input" && !hasAttr(res, "type") {
+		return nil
+	}
+	return res
+}
+
+// allowURL returns whether the URL is relative or has an allowed scheme.
+func (s *sanitizer) allowURL(raw string) bool {
+	u, err := url.Parse(strings.TrimSpace(raw))
+	if err != nil {
+		return false
+	}
+	if u.Scheme == "" {
+		// Reject scheme-like values the parser treats as path, such as "javascript&colon;".
+		return !strings.Contains(strings.SplitN(u.Path, "/", 2)[0], ":")
+	}
+	_, ok := s.schemes[strings.ToLower(u.Scheme)]
+	return ok
+}
+
+func isHeading(name string) bool {
+	return len(name) == 2 && name[0] == 'h' && name[1] >= '1' && name[1] <= '6'
+}
+
+func attr(attrs []html.Attribute, key string) (string, bool) {
+	for _, a := range attrs {
+		if a.Key == key {
+			return a.Val, true
+		}
+	}
+	return "", false
+}
+
+func hasAttr(attrs []html.Attribute, key string) bool {
+	_, ok := attr(attrs, key)
+	return ok
+}
+
+// uniqueIDs prefix the id attribute, and suffix it by a number if it is already used in the document,
+// see [uniqueID].
+func (s *sanitizer) uniqueIDs(ids map[string]int, attrs []html.Attribute) []html.Attribute {
+	for i, a := range attrs {
+		if a.Key == "id" && a.Val != "" {
+			attrs[i].Val = uniqueID(ids, s.idPrefix+a.Val)
+		}
+	}
+	return attrs
+}
+
+// uniqueID returns the id, suffixed by a number if it is already used in the document.
+func uniqueID(ids map[string]int, id string) string {
+	if id == "" {
+		return ""
+	}
+	n := ids[id]
+	ids[id]++
+	if n == 0 {
+		return id
+	}
+	return uniqueID(ids, id+"-"+strconv.Itoa(n))
+}
+
+// slugify returns the heading id of the text, keeping letters and digits of any language.
+func slugify(text string) string {
+	var sb strings.Builder
+	dash := false
+	for _, r := range strings.ToLower(text) {
+		if unicode.IsLetter(r) || unicode.IsDigit(r) || r == '_' {
+			if dash && sb.Len() > 0 {
+				sb.WriteByte('-')
+			}
+			dash = false
+			sb.WriteRune(r)
+			continue
+		}
+		dash = true
+	}
+	return sb.String()
+}
@@ -0,0 +1,50 @@
+package markdown
+
+import "testing"
+
+func TestSanitize(t *testing.T) {
+	s := newSanitizer(DefaultPolicy())
+	tests := []struct {
+		src  string
+		want string
+	}{
+		{`<p onclick="x()">a <b>b</b></p>`, `<p>a b</p>`},
+		{`<script>alert("<p>")</script><p>a</p>`, `<p>a</p>`},
+		{`<object><object></object>x</object>y`, `y`},
+		{`<!-- raw HTML omitted -->a &lt; b`, `a &lt; b`},
+		{`<a href="https://a.com" title="t">a</a>`, `<a href="https://a.com" title="t">a</a>`},
+		{`<a href="/path?q=1#x">a</a>`, `<a href="/path?q=1#x">a</a>`},
+		{`<a href=" JavaScript:alert(1)">a</a>`, `<a>a</a>`},
+		{`<img src="data:image/png;base64,x" alt="x">`, `<img alt="x">`},
+		{`<td style="color:red">a</td>`, `<td>a</td>`},
+		{`<code class="language-go&quot;x">a</code>`, `<code>a</code>`},
+		{`<input type="text" value="x">`, `<input>`},
+		{`<h1>Hello</h1><h2>Hello</h2><h3>Hello</h3>`, `<h1 id="user-content-hello">Hello</h1><h2 id="user-content-hello-1">Hello</h2><h3 id="user-content-hello-2">Hello</h3>`},
+		{`<h3 id="location">location</h3><a id="cookie" href="#">a</a><ol><li id="fn1">b</li></ol>`, `<h3 id="user-content-location">location</h3><a href="#">a</a><ol><li>b</li></ol>`},
+		{`<h2><em>Xin</em> chào!</h2>`, `<h2 id="user-content-xin-chào"><em>Xin</em> chào!</h2>`},
+		{`<h2>!!</h2>`, `<h2>!!</h2>`},
+	}
+	for _, test := range tests {
+		if got := s.sanitize(test.src); got != test.want {
+			t.Fatalf("sanitize %q = %q, want %q", test.src, got, test.want)
+		}
+	}
+}
+
+func TestSanitizeAllowedIDs(t *testing.T) {
+	policy := DefaultPolicy()
+	policy.Elements["div"] = []string{"id"}
+	s := newSanitizer(policy)
+	src := `<div id="intro">a</div><h2>Intro</h2><div id="intro">b</div>`
+	want := `<div id="user-content-intro">a</div><h2 id="user-content-intro-1">Intro</h2><div id="user-content-intro-2">b</div>`
+	if got := s.sanitize(src); got != want {
+		t.Fatalf("sanitize %q = %q, want %q", src, got, want)
+	}
+
+	policy.IDPrefix = ""
+	s = newSanitizer(policy)
+	want = `<div id="intro">a</div><h2 id="intro-1">Intro</h2><div id="intro-2">b</div>`
+	if got := s.sanitize(src); got != want {
+		t.Fatalf("sanitize without prefix %q = %q, want %q", src, got, want)
+	}
+}
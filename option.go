@@ -1,7 +1,9 @@
 package tmpls
 
 import (
+	"context"
 	"io"
+	"io/fs"
 	"strings"
 )
 
@@ -17,10 +19,16 @@ type templatesOptions struct {
 	texmode       bool
 	extensions    map[string]struct{}
 	prefixMap     map[string]string
+	mounts        []mount
 
 	funcs           FuncMap
+	mountFuncs      FuncMap
+	contextFuncs    []ContextFuncsFn
+	localeFuncs     []localeFuncs
 	excludeFuncs    []string
 	disableBuiltins bool
+	safeFuncs       bool
+	encodingFuncs   bool
 
 	preloadMatcher func(name string, path string) bool
 	onExecute      OnTemplateExecuteFn
@@ -66,6 +74,24 @@ func WithPrefixMap(keyValues ...string) TemplatesOption {
 	}
 }
 
+// WithMount add a file system of fallback templates,
+// which are only used when there is no template of the same name in the main file system
+// or in previously mounted file systems.
+//
+// The mounted templates are named using the same rules as the main file system.
+func WithMount(fsys fs.FS) TemplatesOption {
+	return func(options *templatesOptions) {
+		options.mounts = append(options.mounts, mount{fs: fsys})
+	}
+}
+
+// mount is a fallback file system of templates.
+type mount struct {
+	fs fs.FS
+	// extensions overrides the configured extensions if not empty.
+	extensions []string
+}
+
 // WithNocache disable or enable the template cache.
 func WithNocache(nocache bool) TemplatesOption {
 	return func(options *templatesOptions) {
@@ -115,6 +141,42 @@ func WithFuncs(funcs FuncMap) TemplatesOption {
 	}
 }
 
+// ContextFuncsFn returns the template functions bound to the execution context.
+type ContextFuncsFn func(ctx context.Context) FuncMap
+
+// WithContextFuncs add template functions that are bound per execution,
+// using the context passed to [Templates.ExecuteTemplateContext].
+// The function is called with [context.Background] on parsing to get the default functions,
+// so it must return the same function names for any context.
+//
+// Templates are cloned on each execution when this option is used.
+func WithContextFuncs(fn ContextFuncsFn) TemplatesOption {
+	return func(options *templatesOptions) {
+		options.contextFuncs = append(options.contextFuncs, fn)
+	}
+}
+
+// LocaleFuncsFn returns the template functions bound to the locale.
+type LocaleFuncsFn func(locale string) FuncMap
+
+// localeFuncs the locale functions and the resolver of the locale they are bound to.
+type localeFuncs struct {
+	resolve func(locale string) string
+	funcs   LocaleFuncsFn
+}
+
+// WithLocaleFuncs add template functions bound to the locale of each execution,
+// which is set using [ContextWithLocale] and passed to [Templates.ExecuteTemplateContext].
+//
+// The resolve function returns the supported locale used for the requested locale,
+// or an empty string for the default functions, which are fn("") and bound on parsing.
+// Unlike [WithContextFuncs], templates are only cloned once per resolved locale and reused.
+func WithLocaleFuncs(resolve func(locale string) string, fn LocaleFuncsFn) TemplatesOption {
+	return func(options *templatesOptions) {
+		options.localeFuncs = append(options.localeFuncs, localeFuncs{resolve: resolve, funcs: fn})
+	}
+}
+
 // WithoutBuiltinFuncs exclude built-in functions.
 // if no function name is passed, all built-in functions will be excluded.
 func WithoutBuiltinFuncs(funcNames ...string) TemplatesOption {
@@ -126,6 +188,29 @@ func WithoutBuiltinFuncs(funcNames ...string) TemplatesOption {
 	}
 }
 
+// WithSafeFuncs add the functions for marking trusted content:
+// safeHTML, safeURL, safeJS, safeCSS, safeAttr, toJSON and jsonScript.
+// These functions bypass the html/template escaping, so they must only be used with trusted values.
+//
+// Ignored in text mode, see [WithTextMode].
+func WithSafeFuncs() TemplatesOption {
+	return func(options *templatesOptions) {
+		options.safeFuncs = true
+	}
+}
+
+// WithEncodingFuncs add the functions for encoding and hashing:
+// b64enc, b64dec, urlencode, urldecode, sha256sum, hmac, uuid, toJSON, fromJSON and toPrettyJSON.
+// Available in both HTML and text mode.
+//
+// In HTML mode, toJSON returns a string escaped like any other string,
+// and is replaced by the toJSON of [WithSafeFuncs] if both options are used.
+func WithEncodingFuncs() TemplatesOption {
+	return func(options *templatesOptions) {
+		options.encodingFuncs = true
+	}
+}
+
 // WithOnExecute set a callback function that runs before the template is executed.
 func WithOnExecute(callback OnTemplateExecuteFn) TemplatesOption {
 	return func(options *templatesOptions) {
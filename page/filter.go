@@ -0,0 +1,240 @@
+package page
+
+import (
+	"errors"
+	"fmt"
+	"net/url"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// FilterOp is the comparison operator of a [Filter].
+type FilterOp string
+
+const (
+	OpEq   FilterOp = "eq"
+	OpNe   FilterOp = "ne"
+	OpIn   FilterOp = "in"
+	OpGte  FilterOp = "gte"
+	OpLte  FilterOp = "lte"
+	OpLike FilterOp = "like"
+)
+
+// filterOps all supported operators, except [OpEq] which has no param suffix.
+var filterOps = []FilterOp{OpNe, OpIn, OpGte, OpLte, OpLike}
+
+// FilterType is the value type of filter field.
+type FilterType int
+
+const (
+	// FilterString values are kept as string.
+	FilterString FilterType = iota
+	// FilterInt values are parsed as int64.
+	FilterInt
+	// FilterFloat values are parsed as float64.
+	FilterFloat
+	// FilterBool values are parsed as bool.
+	FilterBool
+	// FilterDate values are parsed as [time.Time] from [time.DateOnly] layout.
+	FilterDate
+	// FilterTime values are parsed as [time.Time] from [time.RFC3339] or [time.DateTime] layout.
+	FilterTime
+)
+
+// ErrFilterOpNotAllowed is returned when the filter operator is not allowed for the field.
+var ErrFilterOpNotAllowed = errors.New("filter operator not allowed")
+
+// FilterError is returned when a filter param is rejected by a strict [FilterPolicy].
+type FilterError struct {
+	Param string
+	Value string
+	Err   error
+}
+
+func (e *FilterError) Error() string {
+	return fmt.Sprintf("invalid filter %s=%q: %s", e.Param, e.Value, e.Err.Error())
+}
+
+func (e *FilterError) Unwrap() error {
+	return e.Err
+}
+
+// FilterField declares an allowed filter field.
+type FilterField struct {
+	Type FilterType
+	// Ops the allowed operators.
+	// Empty means only [OpEq] and [OpIn] are allowed.
+	Ops []FilterOp
+}
+
+// allows return whether the operator is allowed for this field.
+func (f FilterField) allows(op FilterOp) bool {
+	if len(f.Ops) == 0 {
+		return op == OpEq || op == OpIn
+	}
+	return slices.Contains(f.Ops, op)
+}
+
+// parse return the typed value of the raw value.
+func (f FilterField) parse(raw string) (any, error) {
+	switch f.Type {
+	case FilterInt:
+		return strconv.ParseInt(raw, 10, 64)
+	case FilterFloat:
+		return strconv.ParseFloat(raw, 64)
+	case FilterBool:
+		return strconv.ParseBool(raw)
+	case FilterDate:
+		return time.Parse(time.DateOnly, raw)
+	case FilterTime:
+		if t, err := time.Parse(time.RFC3339, raw); err == nil {
+			return t, nil
+		}
+		return time.Parse(time.DateTime, raw)
+	default:
+		return raw, nil
+	}
+}
+
+// FilterPolicy declares which fields can be filtered, and how.
+//
+// Filters are read from the query params named after the field with an optional operator suffix:
+//   - status=active: [OpEq], or [OpIn] when the param is repeated (tag=a&tag=b).
+//   - created_gte=2024-01-01: any other operator, [OpIn] also accept comma separated values.
+type FilterPolicy struct {
+	// Fields maps allowed fields to their declaration.
+	Fields map[string]FilterField
+	// Strict whether invalid filters are rejected with [FilterError] instead of silently dropped.
+	Strict bool
+}
+
+// Parse return the filters from the query params.
+// Params that are not declared are ignored.
+//
+// Invalid filters are dropped, or rejected with [FilterError] if the policy is strict.
+// The returned filters are always valid even if an error is returned.
+func (p FilterPolicy) Parse(query url.Values) (Filters, error) {
+	names := make([]string, 0, len(p.Fields))
+	for name := range p.Fields {
+		names = append(names, name)
+	}
+	slices.Sort(names)
+
+	var errs []error
+	filters := make(Filters, 0, len(query))
+	for _, name := range names {
+		field := p.Fields[name]
+		for _, op := range append([]FilterOp{OpEq}, filterOps...) {
+			param := filterParam(name, op)
+			raws, ok := query[param]
+			if !ok || len(raws) == 0 {
+				continue
+			}
+			if op == OpEq && len(raws) > 1 {
+				op = OpIn
+			}
+			if op == OpIn {
+				raws = splitValues(raws)
+			}
+			if !field.allows(op) {
+				errs = append(errs, &FilterError{Param: param, Value: strings.Join(raws, ","), Err: ErrFilterOpNotAllowed})
+				continue
+			}
+
+			filter := Filter{Field: name, Op: op, Raw: make([]string, 0, len(raws)), Values: make([]any, 0, len(raws))}
+			for _, raw := range raws {
+				value, err := field.parse(raw)
+				if err != nil {
+					errs = append(errs, &FilterError{Param: param, Value: raw, Err: err})
+					continue
+				}
+				filter.Raw = append(filter.Raw, raw)
+				filter.Values = append(filter.Values, value)
+			}
+			if len(filter.Values) > 0 {
+				filters = append(filters, filter)
+			}
+		}
+	}
+	if !p.Strict {
+		return filters, nil
+	}
+	return filters, errors.Join(errs...)
+}
+
+// Filter is a parsed filter.
+type Filter struct {
+	Field string
+	Op    FilterOp
+	// Values the typed values, see [FilterType].
+	Values []any
+	// Raw the raw values from the query params.
+	Raw []string
+}
+
+// Value return the first typed value, or nil.
+func (f Filter) Value() any {
+	if len(f.Values) == 0 {
+		return nil
+	}
+	return f.Values[0]
+}
+
+// Filters is a list of [Filter].
+type Filters []Filter
+
+// Has return whether there is any filter on the field.
+func (f Filters) Has(field string) bool {
+	for _, filter := range f {
+		if filter.Field == field {
+			return true
+		}
+	}
+	return false
+}
+
+// Get return the filters on the field.
+func (f Filters) Get(field string) Filters {
+	res := make(Filters, 0, 1)
+	for _, filter := range f {
+		if filter.Field == field {
+			res = append(res, filter)
+		}
+	}
+	return res
+}
+
+// Raw return the first raw value of the filter on the field with the operator,
+// useful for filling form input.
+// Return an empty string if there is no such filter.
+func (f Filters) Raw(field string, op FilterOp) string {
+	for _, filter := range f {
+		if filter.Field == field && filter.Op == op && len(filter.Raw) > 0 {
+			return filter.Raw[0]
+		}
+	}
+	return ""
+}
+
+// filterParam return the query param name of the field and operator.
+func filterParam(field string, op FilterOp) string {
+	if op == OpEq {
+		return field
+	}
+	return field + "_" + string(op)
+}
+
+// splitValues split comma separated values.
+func splitValues(values []string) []string {
+	res := make([]string, 0, len(values))
+	for _, value := range values {
+		for _, v := range strings.Split(value, ",") {
+			if v = strings.TrimSpace(v); v != "" {
+				res = append(res, v)
+			}
+		}
+	}
+	return res
+}
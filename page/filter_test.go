@@ -0,0 +1,53 @@
+package page
+
+import (
+	"errors"
+	"net/url"
+	"testing"
+	"time"
+)
+
+func TestFilterPolicy(t *testing.T) {
+	policy := FilterPolicy{
+		Fields: map[string]FilterField{
+			"status":  {},
+			"created": {Type: FilterDate, Ops: []FilterOp{OpGte, OpLte}},
+			"tag":     {},
+			"age":     {Type: FilterInt, Ops: []FilterOp{OpEq, OpGte}},
+		},
+	}
+	u, _ := url.Parse("/items?page=2&status=active&created_gte=2024-01-01&tag=a&tag=b&age=old&other=1")
+	config := PagingConfig{FilterPolicy: &policy}
+	p, err := NewPagingWithConfig(u, config)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	filters := p.Filters()
+	if len(filters) != 3 {
+		t.Fatalf("unexpected filters %+v", filters)
+	}
+	if created := filters.Get("created"); len(created) != 1 || created[0].Op != OpGte ||
+		!created[0].Value().(time.Time).Equal(time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)) {
+		t.Fatalf("unexpected created filter %+v", created)
+	}
+	if tags := filters.Get("tag"); len(tags) != 1 || tags[0].Op != OpIn || len(tags[0].Values) != 2 {
+		t.Fatalf("unexpected tag filter %+v", tags)
+	}
+	if filters.Has("age") || filters.Raw("status", OpEq) != "active" {
+		t.Fatalf("unexpected filters %+v", filters)
+	}
+
+	policy.Strict = true
+	if _, err := NewPagingWithConfig(u, config); !errors.As(err, new(*FilterError)) {
+		t.Fatalf("expected filter error, got %v", err)
+	}
+
+	page := NewPage[any](p, nil, 100)
+	if path := page.PathWithFilter("status", "archived"); path != "/items?age=old&created_gte=2024-01-01&other=1&status=archived&tag=a&tag=b" {
+		t.Fatalf("unexpected path %q", path)
+	}
+	if path := page.PathWithoutFilter("created"); path != "/items?age=old&other=1&status=active&tag=a&tag=b" {
+		t.Fatalf("unexpected path %q", path)
+	}
+}
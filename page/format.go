@@ -0,0 +1,169 @@
+package page
+
+import (
+	"encoding/json"
+	"fmt"
+	"github.com/mawngo/go-tmpls/v2/simplepage"
+	"net/http"
+)
+
+// Format is the JSON serialization format of a page.
+type Format string
+
+const (
+	// FormatEnvelope serialize page as [Envelope].
+	FormatEnvelope Format = "envelope"
+	// FormatJSONAPI serialize page as [JSONAPIDocument].
+	FormatJSONAPI Format = "jsonapi"
+	// FormatHAL serialize page as [HALDocument].
+	FormatHAL Format = "hal"
+)
+
+// ContentType return the media type of the format.
+func (f Format) ContentType() string {
+	switch f {
+	case FormatJSONAPI:
+		return "application/vnd.api+json"
+	case FormatHAL:
+		return "application/hal+json"
+	default:
+		return "application/json"
+	}
+}
+
+// Encode returns the document of the page in the given format, ready for JSON marshaling.
+// Unknown format is encoded as [FormatEnvelope].
+func Encode[T any](format Format, r *http.Request, p simplepage.Paged[T]) any {
+	switch format {
+	case FormatJSONAPI:
+		return NewJSONAPIDocument(r, p)
+	case FormatHAL:
+		return NewHALDocument(r, p)
+	default:
+		return NewEnvelope(r, p)
+	}
+}
+
+// Decode parse the JSON document in the given format back to a page.
+// The result is a [simplepage.Page] if the document contains totals, otherwise a [simplepage.Slice].
+func Decode[T any](format Format, data []byte) (simplepage.Paged[T], error) {
+	switch format {
+	case FormatEnvelope:
+		var doc Envelope[T]
+		if err := json.Unmarshal(data, &doc); err != nil {
+			return nil, err
+		}
+		return doc.Paged(), nil
+	case FormatJSONAPI:
+		var doc JSONAPIDocument[T]
+		if err := json.Unmarshal(data, &doc); err != nil {
+			return nil, err
+		}
+		return doc.Paged(), nil
+	case FormatHAL:
+		var doc HALDocument[T]
+		if err := json.Unmarshal(data, &doc); err != nil {
+			return nil, err
+		}
+		return doc.Paged(), nil
+	default:
+		return nil, fmt.Errorf("unknown page format %q", format)
+	}
+}
+
+// Paged returns the page of this envelope.
+func (e Envelope[T]) Paged() simplepage.Paged[T] {
+	return newPaged(e.Meta, e.Items)
+}
+
+// JSONAPIDocument is the JSON:API compatible document of a page.
+// Items are serialized as is into the data member,
+// so they should be resource objects for strict compliance.
+type JSONAPIDocument[T any] struct {
+	Data  []T   `json:"data"`
+	Links Links `json:"links"`
+	Meta  Meta  `json:"meta"`
+}
+
+// NewJSONAPIDocument returns the JSON:API document of the page.
+func NewJSONAPIDocument[T any](r *http.Request, p simplepage.Paged[T]) JSONAPIDocument[T] {
+	envelope := NewEnvelope(r, p)
+	return JSONAPIDocument[T]{
+		Data:  envelope.Items,
+		Links: envelope.Links,
+		Meta:  envelope.Meta,
+	}
+}
+
+// Paged returns the page of this document.
+func (d JSONAPIDocument[T]) Paged() simplepage.Paged[T] {
+	return newPaged(d.Meta, d.Data)
+}
+
+// HALRelItems the relation of embedded items in [HALDocument].
+const HALRelItems = "items"
+
+// HALLink is the HAL link object.
+type HALLink struct {
+	Href string `json:"href"`
+}
+
+// HALDocument is the HAL compatible document of a page.
+// Items are embedded under the [HALRelItems] relation, and the metadata is the page property.
+type HALDocument[T any] struct {
+	Embedded map[string][]T     `json:"_embedded"`
+	Links    map[string]HALLink `json:"_links"`
+	Page     Meta               `json:"page"`
+}
+
+// NewHALDocument returns the HAL document of the page.
+func NewHALDocument[T any](r *http.Request, p simplepage.Paged[T]) HALDocument[T] {
+	envelope := NewEnvelope(r, p)
+	links := make(map[string]HALLink, 5)
+	for rel, href := range map[string]string{
+		"self":  envelope.Links.Self,
+		"first": envelope.Links.First,
+		"prev":  envelope.Links.Prev,
+		"next":  envelope.Links.Next,
+		"last":  envelope.Links.Last,
+	} {
+		if href != "" {
+			links[rel] = HALLink{Href: href}
+		}
+	}
+	return HALDocument[T]{
+		Embedded: map[string][]T{HALRelItems: envelope.Items},
+		Links:    links,
+		Page:     envelope.Meta,
+	}
+}
+
+// Paged returns the page of this document.
+func (d HALDocument[T]) Paged() simplepage.Paged[T] {
+	return newPaged(d.Page, d.Embedded[HALRelItems])
+}
+
+// newPaged reconstruct page from the metadata.
+func newPaged[T any](meta Meta, items []T) simplepage.Paged[T] {
+	slice := simplepage.Slice[T]{
+		Items:      items,
+		HasNext:    meta.HasNext,
+		HasPrev:    meta.HasPrev,
+		PageNumber: meta.Page,
+		PageSize:   meta.PageSize,
+		Sorts:      meta.Sorts,
+		IsUnpaged:  meta.IsUnpaged,
+	}
+	if meta.TotalItems == nil {
+		return slice
+	}
+	page := simplepage.Page[T]{
+		Slice:         slice,
+		TotalItems:    *meta.TotalItems,
+		IsTotalCapped: meta.IsTotalCapped,
+	}
+	if meta.TotalPages != nil {
+		page.TotalPages = *meta.TotalPages
+	}
+	return page
+}
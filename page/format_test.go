@@ -0,0 +1,58 @@
+package page
+
+import (
+	"encoding/json"
+	"github.com/mawngo/go-tmpls/v2/simplepage"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+)
+
+type formatItem struct {
+	ID   int    `json:"id"`
+	Name string `json:"name"`
+}
+
+func TestFormatRoundTrip(t *testing.T) {
+	r := httptest.NewRequest("GET", "/items?page=2&size=2&sorts=-name", nil)
+	paging := NewPaging(r.URL)
+	items := []formatItem{{ID: 1, Name: "b"}, {ID: 2, Name: "a"}}
+
+	cases := map[string]simplepage.Paged[formatItem]{
+		"page":  NewPage(paging, items, 7).Page,
+		"slice": simplepage.NewSlice(paging, items, true),
+	}
+	for name, p := range cases {
+		for _, format := range []Format{FormatEnvelope, FormatJSONAPI, FormatHAL} {
+			b, err := json.Marshal(Encode(format, r, p))
+			if err != nil {
+				t.Fatalf("%s %s: marshal: %v", name, format, err)
+			}
+			decoded, err := Decode[formatItem](format, b)
+			if err != nil {
+				t.Fatalf("%s %s: decode: %v", name, format, err)
+			}
+			if !reflect.DeepEqual(decoded, p) {
+				t.Fatalf("%s %s: expected %+v, got %+v", name, format, p, decoded)
+			}
+		}
+	}
+}
+
+func TestFormatLinks(t *testing.T) {
+	r := httptest.NewRequest("GET", "/items?page=2&size=2", nil)
+	p := NewPage(NewPaging(r.URL), []formatItem{{ID: 1}}, 7)
+
+	var doc map[string]any
+	b, _ := json.Marshal(Encode[formatItem](FormatHAL, r, p))
+	if err := json.Unmarshal(b, &doc); err != nil {
+		t.Fatal(err)
+	}
+	links := doc["_links"].(map[string]any)
+	if links["next"].(map[string]any)["href"] != "http://example.com/items?page=3&size=2" {
+		t.Fatalf("unexpected next link %v", links["next"])
+	}
+	if links["last"].(map[string]any)["href"] != "http://example.com/items?page=4&size=2" {
+		t.Fatalf("unexpected last link %v", links["last"])
+	}
+}
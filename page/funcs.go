@@ -0,0 +1,134 @@
+package page
+
+import (
+	"github.com/mawngo/go-tmpls/v2/simplepage"
+	"strconv"
+)
+
+// FuncMap returns the template functions for rendering pages,
+// which can be registered using tmpls.WithFuncs.
+//
+//   - pageInfo: returns the [PageInfo] of the page.
+//   - pageRange: returns the range of items in the page, for example "25–48".
+//   - pageSizes: returns the [PageSizeLink] of each given size, or of [Page.PageSizes] if no size is given,
+//     for rendering a size selector.
+//   - sortLabel: returns the sort label of the page, or the direction arrow of the given field.
+func FuncMap() map[string]any {
+	return map[string]any{
+		"pageInfo":  NewPageInfo,
+		"pageRange": pageRange,
+		"pageSizes": pageSizes,
+		"sortLabel": sortLabel,
+	}
+}
+
+// PageInfo is the summary of a page, for rendering "Showing 25–48 of 1,203".
+type PageInfo struct {
+	// From the 1-based position of the first item in the page, 0 if the page is empty.
+	From int64
+	// To the 1-based position of the last item in the page, 0 if the page is empty.
+	To int64
+	// Total the total items count, only available if HasTotal.
+	Total      int64
+	TotalPages int
+	HasTotal   bool
+	// IsTotalCapped see [simplepage.Page.IsTotalCapped].
+	IsTotalCapped bool
+	Page          int
+	PageSize      int
+}
+
+// NewPageInfo returns the [PageInfo] of the page.
+func NewPageInfo(p PagedData) PageInfo {
+	pageable := p.GetPageable()
+	info := PageInfo{
+		Page:     pageable.PageNumber(),
+		PageSize: pageable.PageSize(),
+	}
+	if n := itemCount(p); n > 0 {
+		offset := int64(0)
+		if !pageable.IsUnpaged() {
+			offset = pageable.PageOffset()
+		}
+		info.From = offset + 1
+		info.To = offset + int64(n)
+	}
+	if total, ok := p.(simplepage.Totaler); ok {
+		info.HasTotal = true
+		info.Total = total.GetTotalItems()
+		info.TotalPages = total.GetTotalPages()
+	}
+	info.IsTotalCapped = isTotalCapped(p)
+	return info
+}
+
+// PageSizeLink is a link to change the page size.
+type PageSizeLink struct {
+	Size      int
+	Path      string
+	IsCurrent bool
+}
+
+// pageSizes returns the links to change page size to each of the given sizes,
+// or to each of the page sizes of the page if no size is given.
+func pageSizes(p PagedData, sizes ...int) []PageSizeLink {
+	if lister, ok := p.(sizeLister); ok && len(sizes) == 0 {
+		sizes = lister.PageSizes()
+	}
+	current := p.GetPageable().PageSize()
+	sizer, _ := p.(sizePather)
+	links := make([]PageSizeLink, 0, len(sizes))
+	for _, size := range sizes {
+		link := PageSizeLink{Size: size, IsCurrent: size == current}
+		if sizer != nil {
+			link.Path = sizer.PathToSize(size)
+		}
+		links = append(links, link)
+	}
+	return links
+}
+
+// pageRange returns the range of items in the page, for example "25–48".
+// Returns an empty string if the page is empty.
+func pageRange(p PagedData) string {
+	info := NewPageInfo(p)
+	if info.From == 0 {
+		return ""
+	}
+	return strconv.FormatInt(info.From, 10) + "–" + strconv.FormatInt(info.To, 10)
+}
+
+// sortLabel returns the label of the page sorts, see [Sorts.LabelStrict].
+// If the field is given, returns the direction arrow of that field, or an empty string if it is not sorted.
+func sortLabel(p PagedData, field ...string) string {
+	sorts := p.GetSorts()
+	if len(field) == 0 {
+		return sorts.LabelStrict()
+	}
+	for _, sort := range sorts {
+		if sort.Field != field[0] {
+			continue
+		}
+		if sort.IsDesc {
+			return "↓"
+		}
+		return "↑"
+	}
+	return ""
+}
+
+// itemCount returns the number of items in the page, as [PagedData] does not expose the items.
+func itemCount(p PagedData) int {
+	if counter, ok := p.(simplepage.ItemCounter); ok {
+		return counter.ItemCount()
+	}
+	return 0
+}
+
+// isTotalCapped returns whether the total of the page is capped.
+func isTotalCapped(p PagedData) bool {
+	if capper, ok := p.(simplepage.TotalCapper); ok {
+		return capper.TotalCapped()
+	}
+	return false
+}
@@ -0,0 +1,41 @@
+package page
+
+import (
+	"html/template"
+	"net/url"
+	"strings"
+	"testing"
+)
+
+func TestFuncMap(t *testing.T) {
+	tmpl := template.Must(template.New("test").Funcs(FuncMap()).Parse(
+		`{{ with pageInfo . }}Showing {{ .From }}–{{ .To }} of {{ .Total }}{{ end }}|` +
+			`{{ pageRange . }}|{{ sortLabel . }}|{{ sortLabel . "name" }}|` +
+			`{{ range pageSizes . 12 24 }}{{ .Size }}:{{ .Path }}:{{ .IsCurrent }} {{ end }}`,
+	))
+
+	u, _ := url.Parse("/items?page=2&size=24&sorts=-name")
+	var sb strings.Builder
+	if err := tmpl.Execute(&sb, NewPage(NewPaging(u), make([]int, 24), 1203)); err != nil {
+		t.Fatal(err)
+	}
+	expected := "Showing 25–48 of 1203|25–48|name ↓|↓|12:/items?size=12&amp;sorts=-name:false 24:/items?size=24&amp;sorts=-name:true "
+	if sb.String() != expected {
+		t.Fatalf("expected %q, got %q", expected, sb.String())
+	}
+}
+
+func TestNewPageInfo(t *testing.T) {
+	u, _ := url.Parse("/items?page=2&size=10")
+	p := NewPage(NewPaging(u), make([]int, 10), 1000)
+	p.IsTotalCapped = true
+	info := NewPageInfo(p)
+	if info.From != 11 || info.To != 20 || !info.HasTotal || info.Total != 1000 || !info.IsTotalCapped {
+		t.Fatalf("unexpected page info %+v", info)
+	}
+
+	info = NewPageInfo(p.Slice)
+	if info.From != 11 || info.To != 20 || info.HasTotal || info.IsTotalCapped {
+		t.Fatalf("unexpected slice info %+v", info)
+	}
+}
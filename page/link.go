@@ -0,0 +1,209 @@
+package page
+
+import (
+	"github.com/mawngo/go-tmpls/v2/simplepage"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+)
+
+const (
+	// HeaderLink the RFC 8288 Link header.
+	HeaderLink = "Link"
+	// HeaderTotalCount the total items count header.
+	HeaderTotalCount = "X-Total-Count"
+)
+
+// Links is the navigation links of a page, as absolute URLs.
+// Links that are not applicable are empty.
+type Links struct {
+	Self  string `json:"self"`
+	First string `json:"first,omitempty"`
+	Prev  string `json:"prev,omitempty"`
+	Next  string `json:"next,omitempty"`
+	Last  string `json:"last,omitempty"`
+}
+
+// Meta is the pagination metadata of a page.
+// Totals are only available for [Page] and [simplepage.Page].
+type Meta struct {
+	Page       int    `json:"page"`
+	PageSize   int    `json:"pageSize"`
+	HasNext    bool   `json:"hasNext"`
+	HasPrev    bool   `json:"hasPrev"`
+	TotalPages *int   `json:"totalPages,omitempty"`
+	TotalItems *int64 `json:"totalItems,omitempty"`
+	Sorts      Sorts  `json:"sorts,omitempty"`
+	IsUnpaged  bool   `json:"isUnpaged,omitempty"`
+	// IsTotalCapped see [simplepage.Page.IsTotalCapped].
+	IsTotalCapped bool `json:"isTotalCapped,omitempty"`
+}
+
+// Envelope is the JSON envelope of a page, with navigation links and metadata.
+type Envelope[T any] struct {
+	Items []T   `json:"items"`
+	Links Links `json:"links"`
+	Meta  Meta  `json:"meta"`
+}
+
+// NewMeta returns the pagination metadata of the page.
+// Totals of other page types are taken from [simplepage.Totaler] and [simplepage.TotalCapper] if implemented.
+func NewMeta[T any](p simplepage.Paged[T]) Meta {
+	var slice simplepage.Slice[T]
+	var page *simplepage.Page[T]
+	switch v := any(p).(type) {
+	case Page[T]:
+		slice, page = v.Slice, &v.Page
+	case *Page[T]:
+		slice, page = v.Slice, &v.Page
+	case simplepage.Page[T]:
+		slice, page = v.Slice, &v
+	case *simplepage.Page[T]:
+		slice, page = v.Slice, v
+	case simplepage.Slice[T]:
+		slice = v
+	case *simplepage.Slice[T]:
+		slice = *v
+	default:
+		pageable := p.GetPageable()
+		slice = simplepage.Slice[T]{
+			HasPrev:    pageable.PageNumber() > 1,
+			PageNumber: pageable.PageNumber(),
+			PageSize:   pageable.PageSize(),
+			Sorts:      pageable.PageSorts(),
+			IsUnpaged:  pageable.IsUnpaged(),
+		}
+		if totaler, ok := p.(simplepage.Totaler); ok {
+			page = &simplepage.Page[T]{
+				TotalPages: totaler.GetTotalPages(),
+				TotalItems: totaler.GetTotalItems(),
+			}
+			if capper, ok := p.(simplepage.TotalCapper); ok {
+				page.IsTotalCapped = capper.TotalCapped()
+			}
+			slice.HasNext = slice.PageNumber < page.TotalPages
+		}
+		if next, ok := p.(hasNexter); ok {
+			slice.HasNext = next.HasNext()
+		}
+	}
+
+	meta := Meta{
+		Page:      slice.PageNumber,
+		PageSize:  slice.PageSize,
+		HasNext:   slice.HasNext,
+		HasPrev:   slice.HasPrev,
+		Sorts:     slice.Sorts,
+		IsUnpaged: slice.IsUnpaged,
+	}
+	if page != nil {
+		meta.TotalPages = &page.TotalPages
+		meta.TotalItems = &page.TotalItems
+		meta.IsTotalCapped = page.IsTotalCapped
+	}
+	return meta
+}
+
+// NewLinks returns the navigation links of the page,
+// built from the request URL by replacing the page param ([ParamPage] by default).
+//
+// The scheme and host are taken from the request URL if it is absolute,
+// otherwise from the X-Forwarded-Proto header (set by TLS-terminating proxies) or the request TLS state,
+// and the Host header. X-Forwarded-Host is not trusted, so proxies must forward the original Host header.
+func NewLinks[T any](r *http.Request, p simplepage.Paged[T]) Links {
+	return newLinks(requestURL(r), paramsOf(p).Page, NewMeta(p))
+}
+
+func newLinks(u *url.URL, param string, meta Meta) Links {
+	links := Links{Self: u.String()}
+	if meta.IsUnpaged {
+		return links
+	}
+	links.First = pageURL(u, param, simplepage.DefaultPageNumber)
+	if meta.HasPrev {
+		links.Prev = pageURL(u, param, meta.Page-1)
+	}
+	if meta.HasNext {
+		links.Next = pageURL(u, param, meta.Page+1)
+	}
+	if meta.TotalPages != nil {
+		links.Last = pageURL(u, param, max(*meta.TotalPages, simplepage.DefaultPageNumber))
+	}
+	return links
+}
+
+// NewEnvelope returns the JSON envelope of the page.
+func NewEnvelope[T any](r *http.Request, p simplepage.Paged[T]) Envelope[T] {
+	meta := NewMeta(p)
+	items := p.GetItems()
+	if items == nil {
+		items = make([]T, 0)
+	}
+	return Envelope[T]{
+		Items: items,
+		Links: newLinks(requestURL(r), paramsOf(p).Page, meta),
+		Meta:  meta,
+	}
+}
+
+// SetLinkHeaders set the RFC 8288 Link header of the page to the response,
+// with first, prev, next and last relations.
+// The [HeaderTotalCount] header is also set when the total is known, which is not the case for [simplepage.Slice].
+func SetLinkHeaders[T any](w http.ResponseWriter, r *http.Request, p simplepage.Paged[T]) {
+	meta := NewMeta(p)
+	links := newLinks(requestURL(r), paramsOf(p).Page, meta)
+
+	values := make([]string, 0, 4)
+	for _, link := range [][2]string{
+		{links.First, "first"},
+		{links.Prev, "prev"},
+		{links.Next, "next"},
+		{links.Last, "last"},
+	} {
+		if link[0] == "" {
+			continue
+		}
+		values = append(values, "<"+link[0]+`>; rel="`+link[1]+`"`)
+	}
+
+	header := w.Header()
+	if len(values) > 0 {
+		header.Set(HeaderLink, strings.Join(values, ", "))
+	}
+	if meta.TotalItems != nil {
+		header.Set(HeaderTotalCount, strconv.FormatInt(*meta.TotalItems, 10))
+	}
+}
+
+// requestURL return the absolute URL of the request.
+func requestURL(r *http.Request) *url.URL {
+	u := *r.URL
+	if u.Scheme == "" {
+		u.Scheme = "http"
+		if r.TLS != nil {
+			u.Scheme = "https"
+		}
+		// Only the first proxy value is used, and only http and https are accepted.
+		proto, _, _ := strings.Cut(r.Header.Get("X-Forwarded-Proto"), ",")
+		if proto = strings.ToLower(strings.TrimSpace(proto)); proto == "http" || proto == "https" {
+			u.Scheme = proto
+		}
+	}
+	if u.Host == "" {
+		u.Host = r.Host
+	}
+	return &u
+}
+
+// pageURL return the URL to the given page number.
+func pageURL(u *url.URL, param string, page int) string {
+	link := *u
+	query := link.Query()
+	query.Del(param)
+	if page > 1 {
+		query.Set(param, strconv.Itoa(page))
+	}
+	link.RawQuery = query.Encode()
+	return link.String()
+}
@@ -0,0 +1,114 @@
+package page
+
+import (
+	"encoding/json"
+	"github.com/mawngo/go-tmpls/v2/simplepage"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestSetLinkHeaders(t *testing.T) {
+	r := httptest.NewRequest("GET", "/items?page=2&size=2", nil)
+	p := NewPage(NewPaging(r.URL), []int{1, 2}, 7)
+
+	w := httptest.NewRecorder()
+	SetLinkHeaders[int](w, r, p)
+	want := `<http://example.com/items?size=2>; rel="first", <http://example.com/items?size=2>; rel="prev", ` +
+		`<http://example.com/items?page=3&size=2>; rel="next", <http://example.com/items?page=4&size=2>; rel="last"`
+	if got := w.Header().Get(HeaderLink); got != want {
+		t.Fatalf("unexpected link header %q", got)
+	}
+	if w.Header().Get(HeaderTotalCount) != "7" {
+		t.Fatalf("unexpected total count header %q", w.Header().Get(HeaderTotalCount))
+	}
+
+	w = httptest.NewRecorder()
+	SetLinkHeaders(w, r, simplepage.NewSlice(p.GetPageable(), p.Items, false))
+	if w.Header().Get(HeaderTotalCount) != "" {
+		t.Fatalf("slice must not have total count header")
+	}
+	if got := w.Header().Get(HeaderLink); got != `<http://example.com/items?size=2>; rel="first", <http://example.com/items?size=2>; rel="prev"` {
+		t.Fatalf("unexpected link header %q", got)
+	}
+}
+
+// embeddedPage is a custom page type embedding a page.
+type embeddedPage struct {
+	Page[int]
+	Extra string
+}
+
+// customPage is a custom page type only implementing the interfaces.
+type customPage struct {
+	simplepage.Slice[int]
+	total int64
+}
+
+func (p customPage) GetTotalItems() int64 {
+	return p.total
+}
+
+func (p customPage) GetTotalPages() int {
+	return int((p.total + 1) / 2)
+}
+
+func (p customPage) TotalCapped() bool {
+	return true
+}
+
+func TestSetLinkHeadersCustomPage(t *testing.T) {
+	r := httptest.NewRequest("GET", "/items?page=2&size=2", nil)
+	p := NewPage(NewPaging(r.URL), []int{1, 2}, 7)
+	want := `<http://example.com/items?size=2>; rel="first", <http://example.com/items?size=2>; rel="prev", ` +
+		`<http://example.com/items?page=3&size=2>; rel="next", <http://example.com/items?page=4&size=2>; rel="last"`
+
+	for _, page := range []simplepage.Paged[int]{
+		embeddedPage{Page: p},
+		customPage{Slice: simplepage.NewSlice(p.GetPageable(), p.Items, false), total: 7},
+	} {
+		w := httptest.NewRecorder()
+		SetLinkHeaders(w, r, page)
+		if got := w.Header().Get(HeaderLink); got != want {
+			t.Fatalf("unexpected link header of %T %q", page, got)
+		}
+		if w.Header().Get(HeaderTotalCount) != "7" {
+			t.Fatalf("unexpected total count header of %T %q", page, w.Header().Get(HeaderTotalCount))
+		}
+	}
+	if meta := NewMeta[int](customPage{Slice: simplepage.NewSlice(p.GetPageable(), p.Items, false), total: 7}); !meta.IsTotalCapped {
+		t.Fatalf("total capped must be kept")
+	}
+}
+
+func TestNewLinksForwardedProto(t *testing.T) {
+	r := httptest.NewRequest("GET", "/items?page=2", nil)
+	p := NewPage(NewPaging(r.URL), []int{1}, 100)
+	tests := []struct {
+		proto string
+		want  string
+	}{
+		{"", "http://example.com/items?page=3"},
+		{"https", "https://example.com/items?page=3"},
+		{"HTTPS, http", "https://example.com/items?page=3"},
+		{"javascript", "http://example.com/items?page=3"},
+	}
+	for _, test := range tests {
+		r.Header.Set("X-Forwarded-Proto", test.proto)
+		if got := NewLinks[int](r, p).Next; got != test.want {
+			t.Fatalf("proto %q: next = %q, want %q", test.proto, got, test.want)
+		}
+	}
+}
+
+func TestNewEnvelope(t *testing.T) {
+	r := httptest.NewRequest("GET", "/items", nil)
+	b, err := json.Marshal(NewEnvelope[int](r, NewEmptyPage[int](NewPaging(r.URL))))
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := `{"items":[],"links":{"self":"http://example.com/items","first":"http://example.com/items","last":"http://example.com/items"},` +
+		`"meta":{"page":1,"pageSize":24,"hasNext":false,"hasPrev":false,"totalPages":0,"totalItems":0}}`
+	if string(b) != want {
+		t.Fatalf("unexpected envelope %s", b)
+	}
+}
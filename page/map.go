@@ -0,0 +1,55 @@
+package page
+
+import (
+	"context"
+	"github.com/mawngo/go-tmpls/v2/simplepage"
+)
+
+// Map convert the items of the page using fn, preserving the kind and metadata of the page,
+// including the URL state of [Page].
+// See [simplepage.Map] for other kinds.
+func Map[T, U any](p simplepage.Paged[T], fn func(item T) U) simplepage.Paged[U] {
+	return WithItems(p, simplepage.Map(p, fn).GetItems())
+}
+
+// MapErr is [Map] with fn that can fail.
+// The conversion stops at the first error.
+func MapErr[T, U any](p simplepage.Paged[T], fn func(item T) (U, error)) (simplepage.Paged[U], error) {
+	return MapContext(context.Background(), p, func(_ context.Context, item T) (U, error) {
+		return fn(item)
+	})
+}
+
+// MapContext is [MapErr] with context, which stops the conversion when the context is done.
+func MapContext[T, U any](ctx context.Context, p simplepage.Paged[T], fn func(ctx context.Context, item T) (U, error)) (simplepage.Paged[U], error) {
+	// Use simplepage conversion for items, then restore the kind.
+	mapped, err := simplepage.MapContext(ctx, p, fn)
+	if err != nil {
+		return nil, err
+	}
+	return WithItems(p, mapped.GetItems()), nil
+}
+
+// WithItems return a page of the same kind and metadata as p, with items replaced.
+// [Page] keeps its URL state, other kinds are converted by [simplepage.WithItems].
+func WithItems[T, U any](p simplepage.Paged[T], items []U) simplepage.Paged[U] {
+	switch v := any(p).(type) {
+	case Page[T]:
+		return pageWithItems(v, items)
+	case *Page[T]:
+		page := pageWithItems(*v, items)
+		return &page
+	}
+	return simplepage.WithItems(p, items)
+}
+
+func pageWithItems[T, U any](p Page[T], items []U) Page[U] {
+	return Page[U]{
+		Page:    simplepage.WithItems[T, U](p.Page, items).(simplepage.Page[U]),
+		url:     p.url,
+		queries: p.queries,
+		params:  p.params,
+		filters: p.filters,
+		sizes:   p.sizes,
+	}
+}
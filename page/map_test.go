@@ -0,0 +1,44 @@
+package page
+
+import (
+	"errors"
+	"github.com/mawngo/go-tmpls/v2/simplepage"
+	"net/url"
+	"strconv"
+	"testing"
+)
+
+func TestMap(t *testing.T) {
+	u, _ := url.Parse("/items?page=2&size=2&q=go")
+	p := NewPage(NewPaging(u), []int{1, 2}, 7)
+
+	mapped, ok := Map(p, strconv.Itoa).(Page[string])
+	if !ok {
+		t.Fatalf("page kind must be preserved")
+	}
+	if mapped.Items[1] != "2" || mapped.TotalItems != 7 || mapped.Search() != "go" || mapped.PathToNext() != "/items?page=3&q=go&size=2" {
+		t.Fatalf("unexpected mapped page %+v", mapped)
+	}
+
+	if _, ok := Map[int, string](simplepage.NewSlice(p.GetPageable(), p.Items, true), strconv.Itoa).(simplepage.Slice[string]); !ok {
+		t.Fatalf("slice kind must be preserved")
+	}
+	if _, ok := Map[int, string](&p.Page, strconv.Itoa).(*simplepage.Page[string]); !ok {
+		t.Fatalf("pointer kind must be preserved")
+	}
+
+	base, ok := simplepage.Map[int, string](p, strconv.Itoa).(simplepage.Page[string])
+	if !ok || base.TotalItems != 7 || base.TotalPages != 4 || !base.HasNext {
+		t.Fatalf("totals must be preserved by simplepage.Map, got %+v", base)
+	}
+
+	_, err := MapErr(p, func(i int) (string, error) {
+		if i == 2 {
+			return "", errors.New("boom")
+		}
+		return strconv.Itoa(i), nil
+	})
+	if err == nil {
+		t.Fatalf("expected error")
+	}
+}
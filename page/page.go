@@ -15,7 +15,15 @@
 // D Convenient shorthand for map[string]any.
 type D map[string]any
 
-var _ Paged[any] = (*Page[any])(nil)
+var (
+	_ Paged[any]   = (*Page[any])(nil)
+	_ Windowed     = (*Page[any])(nil)
+	_ SortLinker   = (*Page[any])(nil)
+	_ FilterLinker = (*Page[any])(nil)
+
+	_ simplepage.NextPageable = (*Page[any])(nil)
+	_ simplepage.PageSetter   = (*Paging)(nil)
+)
 
 // PagedData interface for casting to any [Paged] type.
 type PagedData interface {
@@ -53,6 +61,27 @@ type Paged[T any] interface {
 	QueryValues() url.Values
 }
 
+// Windowed is implemented by pages that provide numbered page links, see [Page.PageWindow].
+type Windowed interface {
+	PageWindow(n int) []PageLink
+}
+
+// SortLinker is implemented by pages that provide sort state and sort links for table headers,
+// see [Page.SortState].
+type SortLinker interface {
+	SortState(field string) SortState
+	PathToggleSort(field string) string
+	PathAddSort(field string) string
+}
+
+// FilterLinker is implemented by pages that provide parsed filters and filter links,
+// see [Page.Filters].
+type FilterLinker interface {
+	Filters() Filters
+	PathWithFilter(param string, values ...string) string
+	PathWithoutFilter(field string) string
+}
+
 // Page represents a page of data.
 type Page[T any] struct {
 	simplepage.Page[T]
@@ -60,6 +89,42 @@ type Page[T any] struct {
 	url *url.URL
 	// queries request query params.
 	queries url.Values
+	// params names of the paging params.
+	params ParamNames
+	// filters parsed filters.
+	filters Filters
+	// sizes the size policy of the request.
+	sizes *simplepage.SizePolicy
+}
+
+// paramNamer is implemented by [Pageable] that has custom param names.
+type paramNamer interface {
+	Params() ParamNames
+}
+
+// filterer is implemented by [Pageable] that has parsed filters.
+type filterer interface {
+	Filters() Filters
+}
+
+// sizePolicer is implemented by [Pageable] that has a size policy, see [simplepage.Paging.PageSizePolicy].
+type sizePolicer interface {
+	PageSizePolicy() *simplepage.SizePolicy
+}
+
+// hasNexter is implemented by pages that know whether there is a next page, such as [Page].
+type hasNexter interface {
+	HasNext() bool
+}
+
+// sizeLister is implemented by pages that provide the page sizes to choose from, see [Page.PageSizes].
+type sizeLister interface {
+	PageSizes() []int
+}
+
+// sizePather is implemented by pages that provide links to change the page size, see [Page.PathToSize].
+type sizePather interface {
+	PathToSize(size int) string
 }
 
 // NewPage returns a new [Page] from paginator, data, and total items count.
@@ -68,7 +133,34 @@ func NewPage[T any](p Pageable, items []T, total int64) Page[T] {
 		Page:    simplepage.NewPage(p, items, total),
 		url:     p.URL(),
 		queries: p.QueryValues(),
+		params:  paramsOf(p),
+		filters: filtersOf(p),
+		sizes:   sizePolicyOf(p),
+	}
+}
+
+// sizePolicyOf return the size policy of the pageable, or nil if the default limits are used.
+func sizePolicyOf(p any) *simplepage.SizePolicy {
+	if policer, ok := p.(sizePolicer); ok {
+		return policer.PageSizePolicy()
+	}
+	return nil
+}
+
+// filtersOf return the filters of the pageable.
+func filtersOf(p any) Filters {
+	if f, ok := p.(filterer); ok {
+		return f.Filters()
+	}
+	return nil
+}
+
+// paramsOf return the param names of the pageable, or the default names.
+func paramsOf(p any) ParamNames {
+	if namer, ok := p.(paramNamer); ok {
+		return namer.Params().withDefaults()
 	}
+	return ParamNames{}.withDefaults()
 }
 
 // NewEmptyPage returns a new empty [Page].
@@ -81,6 +173,26 @@ func (p Page[T]) HasNext() bool {
 	return p.PageNumber < p.TotalPages
 }
 
+// NextPageable return the [Paging] of the next page, keeping the URL, sort, filter and size policy of the request,
+// or false if this is the last page. Used by [simplepage.Pages] for iterating through pages.
+func (p Page[T]) NextPageable() (simplepage.Pageable, bool) {
+	if p.IsUnpaged || !p.HasNext() {
+		return nil, false
+	}
+	return Paging{
+		Paging: simplepage.Paging{
+			Page:       p.PageNumber,
+			Size:       p.PageSize,
+			Sorts:      p.Sorts,
+			SizePolicy: p.sizes,
+		},
+		url:     p.url,
+		queries: p.queries,
+		params:  p.params,
+		filters: p.filters,
+	}.WithPage(p.NextPage()), true
+}
+
 // CurrentPage return the current page number.
 func (p Page[T]) CurrentPage() int {
 	return p.PageNumber
@@ -130,9 +242,9 @@ func (p Page[T]) PathToLast() string {
 // PathToPage returns the URL path for the given page number.
 func (p Page[T]) PathToPage(page int) string {
 	query := p.url.Query()
-	query.Del(ParamPage)
+	query.Del(p.params.Page)
 	if page > 1 {
-		query.Set(ParamPage, strconv.Itoa(page))
+		query.Set(p.params.Page, strconv.Itoa(page))
 	}
 	if q := query.Encode(); q != "" {
 		return p.url.Path + "?" + q
@@ -144,10 +256,10 @@ func (p Page[T]) PathToPage(page int) string {
 // Changing the size will reset the page to 1.
 func (p Page[T]) PathToSize(size int) string {
 	query := p.url.Query()
-	query.Del(ParamPage)
-	query.Del(ParamSize)
+	query.Del(p.params.Page)
+	query.Del(p.params.Size)
 	if size > 1 {
-		query.Set(ParamSize, strconv.Itoa(size))
+		query.Set(p.params.Size, strconv.Itoa(size))
 	}
 	if q := query.Encode(); q != "" {
 		return p.url.Path + "?" + q
@@ -159,10 +271,10 @@ func (p Page[T]) PathToSize(size int) string {
 // Changing sorts will reset the page to 1.
 func (p Page[T]) PathToSort(sorts ...string) string {
 	query := p.url.Query()
-	query.Del(ParamPage)
-	query.Del(ParamSort)
+	query.Del(p.params.Page)
+	query.Del(p.params.Sort)
 	if len(sorts) > 0 {
-		query[ParamSort] = sorts
+		query[p.params.Sort] = sorts
 	}
 	if q := query.Encode(); q != "" {
 		return p.url.Path + "?" + q
@@ -176,9 +288,9 @@ func (p Page[T]) PathToSort(sorts ...string) string {
 // The query param will be replaced.
 func (p Page[T]) PathToQueryParam(param string, values ...string) string {
 	query := p.url.Query()
-	query.Del(ParamPage)
-	query.Del(ParamSort)
-	query.Del(ParamSearch)
+	query.Del(p.params.Page)
+	query.Del(p.params.Sort)
+	query.Del(p.params.Search)
 	query[param] = values
 	if q := query.Encode(); q != "" {
 		return p.url.Path + "?" + q
@@ -202,14 +314,58 @@ func (p Page[T]) PathWithQueryParam(param string, values ...string) string {
 	return p.url.Path
 }
 
+// PathWithFilter returns the URL path with the filter param replaced.
+// The param is the filter field, with an optional operator suffix, see [FilterPolicy].
+//
+// Changing filters will reset the page to 1.
+func (p Page[T]) PathWithFilter(param string, values ...string) string {
+	query := p.url.Query()
+	query.Del(p.params.Page)
+	query[param] = values
+	if q := query.Encode(); q != "" {
+		return p.url.Path + "?" + q
+	}
+	return p.url.Path
+}
+
+// PathWithoutFilter returns the URL path with all filter params of the field removed.
+//
+// Changing filters will reset the page to 1.
+func (p Page[T]) PathWithoutFilter(field string) string {
+	query := p.url.Query()
+	query.Del(p.params.Page)
+	query.Del(field)
+	for _, op := range filterOps {
+		query.Del(filterParam(field, op))
+	}
+	if q := query.Encode(); q != "" {
+		return p.url.Path + "?" + q
+	}
+	return p.url.Path
+}
+
+// PageSizes return the page sizes to choose from, see [simplepage.SizePolicy.Sizes].
+// Uses the size policy of the request, which is set by [PagingConfig], or the default limits.
+func (p Page[T]) PageSizes() []int {
+	if p.sizes != nil {
+		return p.sizes.Sizes()
+	}
+	return simplepage.SizePolicy{}.Sizes()
+}
+
+// Filters return the parsed filters, see [PagingConfig.FilterPolicy].
+func (p Page[T]) Filters() Filters {
+	return p.filters
+}
+
 // Query return given query param value.
 func (p Page[T]) Query(name string) string {
 	return p.queries.Get(name)
 }
 
-// Search return value of ParamSearch query param, trimmed.
+// Search return value of the search param ([ParamSearch] by default), trimmed.
 func (p Page[T]) Search() string {
-	return strings.TrimSpace(p.queries.Get(ParamSearch))
+	return strings.TrimSpace(p.queries.Get(p.params.Search))
 }
 
 // QueryValues return parsed request query params.
@@ -221,3 +377,8 @@ func (p Page[T]) QueryValues() url.Values {
 func (p Page[T]) URL() *url.URL {
 	return p.url
 }
+
+// Params return the names of the paging params.
+func (p Page[T]) Params() ParamNames {
+	return p.params
+}
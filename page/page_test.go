@@ -0,0 +1,55 @@
+package page
+
+import (
+	"context"
+	"github.com/mawngo/go-tmpls/v2/simplepage"
+	"net/url"
+	"testing"
+)
+
+func TestPageNextPageable(t *testing.T) {
+	u, _ := url.Parse("/items?status=active&sorts=-name&size=10")
+	paging, err := NewPagingWithConfig(u, PagingConfig{
+		AllowedSizes: []int{10, 20},
+		FilterPolicy: &FilterPolicy{Fields: map[string]FilterField{"status": {}}},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	all := make([]int, 25)
+	want := []string{
+		"/items?size=10&sorts=-name&status=active",
+		"/items?page=2&size=10&sorts=-name&status=active",
+		"/items?page=3&size=10&sorts=-name&status=active",
+	}
+	// The fetcher returns either a page.Page, or a simplepage.Page that does not keep the request state.
+	for _, plain := range []bool{false, true} {
+		paths := make([]string, 0, 3)
+		fetch := func(_ context.Context, pageable simplepage.Pageable) (simplepage.Paged[int], error) {
+			p := pageable.(Pageable)
+			page := NewPage(p, all[:10], int64(len(all)))
+			paths = append(paths, page.PathToPage(page.CurrentPage()))
+			if len(page.Filters()) != 1 || page.PageSize != 10 || page.Sorts[0].Field != "name" {
+				t.Fatalf("request state must be kept on page %d", page.CurrentPage())
+			}
+			if plain {
+				return page.Page, nil
+			}
+			return page, nil
+		}
+		for _, err := range simplepage.Pages(context.Background(), paging, fetch) {
+			if err != nil {
+				t.Fatal(err)
+			}
+		}
+		if len(paths) != len(want) {
+			t.Fatalf("unexpected paths %v", paths)
+		}
+		for i := range want {
+			if paths[i] != want[i] {
+				t.Fatalf("path %d = %q, want %q", i, paths[i], want[i])
+			}
+		}
+	}
+}
@@ -1,7 +1,14 @@
 package page
 
 import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
 	"github.com/mawngo/go-tmpls/v2/simplepage"
+	"io"
+	"mime"
+	"net/http"
 	"net/url"
 	"strconv"
 	"strings"
@@ -14,6 +21,19 @@
 	ParamSearch = "q"
 )
 
+// jsonParamSize the page size field name of [simplepage.Paging] in JSON,
+// also accepted in JSON body besides [ParamNames.Size].
+const jsonParamSize = "pageSize"
+
+// maxJSONBodySize the maximum size of JSON body read by [FromRequest].
+const maxJSONBodySize = 1 << 20
+
+// ErrBodyTooLarge is returned by [FromRequest] when the JSON body is larger than 1 MiB.
+var ErrBodyTooLarge = errors.New("request body too large")
+
+// defaultMultipartMemory the maximum memory used when parsing multipart form, same as [http.Request.FormValue].
+const defaultMultipartMemory = 32 << 20
+
 var _ Pageable = (*Paging)(nil)
 
 // Pageable interface for requesting/constructing a page.
@@ -27,6 +47,85 @@ type Pageable interface {
 	QueryValues() url.Values
 }
 
+// ParamNames the names of the paging params.
+// Empty names default to [ParamPage], [ParamSize], [ParamSort] and [ParamSearch].
+type ParamNames struct {
+	Page   string
+	Size   string
+	Sort   string
+	Search string
+}
+
+// withDefaults return the names with empty names replaced by the defaults.
+func (n ParamNames) withDefaults() ParamNames {
+	if n.Page == "" {
+		n.Page = ParamPage
+	}
+	if n.Size == "" {
+		n.Size = ParamSize
+	}
+	if n.Sort == "" {
+		n.Sort = ParamSort
+	}
+	if n.Search == "" {
+		n.Search = ParamSearch
+	}
+	return n
+}
+
+// ParamError is returned when a paging param is invalid and [PagingConfig.Strict] is set.
+type ParamError struct {
+	Param string
+	Value string
+	Err   error
+}
+
+func (e *ParamError) Error() string {
+	return fmt.Sprintf("invalid param %s=%q: %s", e.Param, e.Value, e.Err.Error())
+}
+
+func (e *ParamError) Unwrap() error {
+	return e.Err
+}
+
+// PagingConfig configure how [Paging] is read from the request, so each endpoint can have its own limits.
+// All fields are optional.
+type PagingConfig struct {
+	// Params the names of the paging params.
+	Params ParamNames
+	// DefaultSize the page size used when no size is requested.
+	// Zero means [DefaultPageSize].
+	DefaultSize int
+	// MaxSize the maximum page size.
+	// Zero means [simplepage.MaxPageSize].
+	MaxSize int
+	// AllowedSizes the list of allowed page sizes.
+	// Empty means any size up to MaxSize is allowed.
+	AllowedSizes []int
+	// DefaultSorts the sorts used when no sort is requested.
+	DefaultSorts []string
+	// SortPolicy restrict the requested sorts, see [SortPolicy.Apply].
+	// Invalid sorts are dropped, or rejected with [SortError] if the policy is strict.
+	SortPolicy *SortPolicy
+	// FilterPolicy declares the filters that are parsed from the request, see [FilterPolicy.Parse].
+	FilterPolicy *FilterPolicy
+	// Strict whether invalid page and size params are rejected with [ParamError],
+	// instead of silently corrected.
+	Strict bool
+}
+
+// sizePolicy return the size policy of this config, or nil if the default limits are used.
+func (c PagingConfig) sizePolicy() *simplepage.SizePolicy {
+	if c.DefaultSize <= 0 && c.MaxSize <= 0 && len(c.AllowedSizes) == 0 {
+		return nil
+	}
+	return &simplepage.SizePolicy{
+		Default: c.DefaultSize,
+		Max:     c.MaxSize,
+		Allowed: c.AllowedSizes,
+	}
+}
+
 // Paging represent a page request.
 type Paging struct {
 	simplepage.Paging
@@ -34,6 +133,10 @@ type Paging struct {
 	url *url.URL
 	// queries request query params.
 	queries url.Values
+	// params names of the paging params.
+	params ParamNames
+	// filters parsed filters.
+	filters Filters
 }
 
 // Query return given query param value.
@@ -41,9 +144,9 @@ func (p Paging) Query(name string) string {
 	return p.queries.Get(name)
 }
 
-// Search return value of ParamSearch query param, trimmed.
+// Search return value of the search param ([ParamSearch] by default), trimmed.
 func (p Paging) Search() string {
-	return strings.TrimSpace(p.queries.Get(ParamSearch))
+	return strings.TrimSpace(p.queries.Get(p.params.Search))
 }
 
 // QueryValues return parsed request query params.
@@ -56,12 +159,45 @@ func (p Paging) URL() *url.URL {
 	return p.url
 }
 
+// Params return the names of the paging params.
+func (p Paging) Params() ParamNames {
+	return p.params
+}
+
+// Filters return the parsed filters, see [PagingConfig.FilterPolicy].
+func (p Paging) Filters() Filters {
+	return p.filters
+}
+
+// WithPage return a copy of this paging with the page number replaced, also in the URL and query params.
+// See [simplepage.PageSetter].
+func (p Paging) WithPage(page int) simplepage.Pageable {
+	p.Page = page
+	queries := make(url.Values, len(p.queries)+1)
+	for name, values := range p.queries {
+		queries[name] = values
+	}
+	queries.Set(p.params.Page, strconv.Itoa(page))
+	p.queries = queries
+
+	if p.url != nil {
+		link := *p.url
+		query := link.Query()
+		query.Set(p.params.Page, strconv.Itoa(page))
+		link.RawQuery = query.Encode()
+		p.url = &link
+	}
+	return p
+}
+
 // Unsorted return a [Paging] without sorting.
 func (p Paging) Unsorted() Paging {
 	return Paging{
 		Paging:  p.Paging.Unsorted(),
 		url:     p.url,
 		queries: p.queries,
+		params:  p.params,
+		filters: p.filters,
 	}
 }
 
@@ -75,31 +211,212 @@ func NewDefaultPaging(url *url.URL, sorts ...string) Paging {
 		),
 		queries: url.Query(),
 		url:     url,
+		params:  ParamNames{}.withDefaults(),
 	}
 }
 
 // NewPaging returns a new paginator from the request and optionally default sorts.
+//
+// Any requested sort is accepted. Use [NewPagingWithConfig] with [PagingConfig.SortPolicy]
+// to restrict the sorts that can be requested.
 func NewPaging(url *url.URL, sorts ...string) Paging {
-	p := NewDefaultPaging(url, sorts...)
-	query := p.queries
-	if page := query.Get(ParamPage); page != "" {
-		if pageNumber, err := strconv.Atoi(page); err == nil {
+	// Without sort policy and strict mode, there is no error.
+	p, _ := newPaging(url, url.Query(), PagingConfig{DefaultSorts: sorts})
+	return p
+}
+
+// NewPagingWithConfig returns a new paginator from the request using the given config.
+//
+// Invalid params are silently corrected, or rejected with [ParamError] if [PagingConfig.Strict] is set.
+// Sorts are restricted by [PagingConfig.SortPolicy] if set.
+// The returned [Paging] is always valid, so it is still usable when an error is returned.
+func NewPagingWithConfig(url *url.URL, config PagingConfig) (Paging, error) {
+	return newPaging(url, url.Query(), config)
+}
+
+// FromRequest returns a new paginator from the request URL query, form-encoded body and JSON body.
+// Values in the body take precedence over the URL query.
+//
+// JSON body can contain the page, size, sort and search fields named by [PagingConfig.Params],
+// the size can also be named "pageSize" like [simplepage.Paging],
+// and the sort can be a comma separated string or an array of string.
+// The request body is restored after reading, so it can be read again by the handler.
+// JSON body larger than 1 MiB is rejected with a [ParamError] wrapping [ErrBodyTooLarge].
+//
+// If the body cannot be read, the error is returned with the [Paging] of the URL query.
+// Fields read from the body are also available via [Paging.Query] and [Paging.QueryValues].
+// See [NewPagingWithConfig] for how the params are validated.
+func FromRequest(r *http.Request, config PagingConfig) (Paging, error) {
+	names := config.Params.withDefaults()
+	values := r.URL.Query()
+
+	mediaType, _, _ := mime.ParseMediaType(r.Header.Get("Content-Type"))
+	switch mediaType {
+	case "application/x-www-form-urlencoded":
+		if err := r.ParseForm(); err != nil {
+			return fromURL(r.URL, config, err)
+		}
+		mergeValues(values, r.PostForm)
+	case "multipart/form-data":
+		if err := r.ParseMultipartForm(defaultMultipartMemory); err != nil {
+			return fromURL(r.URL, config, err)
+		}
+		mergeValues(values, r.PostForm)
+	case "application/json":
+		body, err := readJSONParams(r, names)
+		if err != nil {
+			return fromURL(r.URL, config, err)
+		}
+		mergeValues(values, body)
+	default:
+	}
+	return newPaging(r.URL, values, config)
+}
+
+// fromURL returns the paginator from the request URL query only, keeping the config,
+// and the error of reading the body.
+func fromURL(u *url.URL, config PagingConfig, err error) (Paging, error) {
+	p, _ := newPaging(u, u.Query(), config)
+	return p, err
+}
+
+// readJSONParams read the paging params from the JSON body, and restore the body.
+func readJSONParams(r *http.Request, names ParamNames) (url.Values, error) {
+	if r.Body == nil || r.Body == http.NoBody {
+		return nil, nil
+	}
+	body := r.Body
+	b, err := io.ReadAll(io.LimitReader(body, maxJSONBodySize+1))
+	if len(b) > maxJSONBodySize {
+		// Keep the unread part, so the handler can still read (and limit) the whole body.
+		r.Body = struct {
+			io.Reader
+			io.Closer
+		}{io.MultiReader(bytes.NewReader(b), body), body}
+		return nil, &ParamError{Param: "body", Err: ErrBodyTooLarge}
+	}
+	_ = body.Close()
+	r.Body = io.NopCloser(bytes.NewReader(b))
+	if err != nil || len(bytes.TrimSpace(b)) == 0 {
+		return nil, err
+	}
+
+	var fields map[string]json.RawMessage
+	if err := json.Unmarshal(b, &fields); err != nil || fields == nil {
+		return nil, err
+	}
+
+	values := make(url.Values)
+	if _, ok := fields[names.Size]; !ok {
+		fields[names.Size] = fields[jsonParamSize]
+	}
+	for _, name := range []string{names.Page, names.Size} {
+		raw, ok := fields[name]
+		if !ok || raw == nil {
+			continue
+		}
+		var number json.Number
+		if err := json.Unmarshal(raw, &number); err != nil {
+			return nil, err
+		}
+		if number != "" {
+			values.Set(name, number.String())
+		}
+	}
+	if raw, ok := fields[names.Sort]; ok {
+		var sorts Sorts
+		if err := sorts.UnmarshalJSON(raw); err != nil {
+			return nil, err
+		}
+		for _, sort := range sorts {
+			values.Add(names.Sort, sort.String())
+		}
+	}
+	if raw, ok := fields[names.Search]; ok {
+		var search string
+		if err := json.Unmarshal(raw, &search); err != nil {
+			return nil, err
+		}
+		values.Set(names.Search, search)
+	}
+	return values, nil
+}
+
+// mergeValues replace the values in dst by values in src.
+func mergeValues(dst url.Values, src url.Values) {
+	for name, values := range src {
+		dst[name] = values
+	}
+}
+
+// newPaging returns a new paginator from the given params.
+func newPaging(url *url.URL, query url.Values, config PagingConfig) (Paging, error) {
+	p := NewDefaultPaging(url, config.DefaultSorts...)
+	p.queries = query
+	p.params = config.Params.withDefaults()
+	if sizes := config.sizePolicy(); sizes != nil {
+		// Let the policy decide the default size.
+		//nolint:staticcheck
+		p.Size = 0
+		p.SizePolicy = sizes
+	}
+
+	var errs []error
+	if page := query.Get(p.params.Page); page != "" {
+		pageNumber, err := strconv.Atoi(page)
+		switch {
+		case err != nil:
+			errs = append(errs, &ParamError{Param: p.params.Page, Value: page, Err: err})
+		case pageNumber < DefaultPageNumber:
+			errs = append(errs, &ParamError{Param: p.params.Page, Value: page, Err: simplepage.ErrInvalidPageNumber})
+		default:
 			//nolint:staticcheck
 			p.Page = pageNumber
 		}
 	}
-	if size := query.Get(ParamSize); size != "" {
-		if pageSize, err := strconv.Atoi(size); err == nil {
+	if size := query.Get(p.params.Size); size != "" {
+		pageSize, err := strconv.Atoi(size)
+		if err == nil {
+			sizes := simplepage.SizePolicy{}
+			if p.SizePolicy != nil {
+				sizes = *p.SizePolicy
+			}
+			err = sizes.Validate(pageSize)
+		}
+		if err != nil {
+			errs = append(errs, &ParamError{Param: p.params.Size, Value: size, Err: err})
+		}
+		if pageSize > 0 {
 			//nolint:staticcheck
 			p.Size = pageSize
 		}
 	}
-	if !query.Has(ParamSort) {
-		return p
+	if !config.Strict {
+		errs = errs[:0]
+	}
+
+	if s := parseSorts(query[p.params.Sort]); len(s) > 0 {
+		//nolint:staticcheck
+		p.Sorts = s
+	}
+	if config.SortPolicy != nil {
+		s, err := config.SortPolicy.Apply(p.PageSorts())
+		//nolint:staticcheck
+		p.Sorts = s
+		errs = append(errs, err)
+	}
+	if config.FilterPolicy != nil {
+		filters, err := config.FilterPolicy.Parse(query)
+		p.filters = filters
+		errs = append(errs, err)
 	}
+	return p, errors.Join(errs...)
+}
 
-	s := make([]string, 0, len(query[ParamSort]))
-	for _, sorts := range query[ParamSort] {
+// parseSorts parse sorts from a list of comma separated values.
+func parseSorts(values []string) Sorts {
+	s := make([]string, 0, len(values))
+	for _, sorts := range values {
 		for _, sort := range strings.Split(sorts, ",") {
 			sort = strings.TrimSpace(sort)
 			if len(sort) > 0 {
@@ -107,9 +424,8 @@ func NewPaging(url *url.URL, sorts ...string) Paging {
 			}
 		}
 	}
-	if len(s) > 0 {
-		//nolint:staticcheck
-		p.Sorts = simplepage.NewSorts(s)
+	if len(s) == 0 {
+		return nil
 	}
-	return p
+	return simplepage.NewSorts(s)
 }
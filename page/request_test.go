@@ -0,0 +1,122 @@
+package page
+
+import (
+	"errors"
+	"github.com/mawngo/go-tmpls/v2/simplepage"
+	"io"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+)
+
+func TestFromRequest(t *testing.T) {
+	r := httptest.NewRequest("POST", "/search?p=3&sort=name", strings.NewReader(`{"pageSize": 10, "sort": ["-created", "name"], "q": " go "}`))
+	r.Header.Set("Content-Type", "application/json")
+	p, err := FromRequest(r, PagingConfig{Params: ParamNames{Page: "p", Sort: "sort"}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if p.PageNumber() != 3 || p.PageSize() != 10 || p.Search() != "go" {
+		t.Fatalf("unexpected paging %+v", p.Paging)
+	}
+	if sorts := p.PageSorts(); len(sorts) != 2 || sorts[0].Field != "created" || !sorts[0].IsDesc {
+		t.Fatalf("unexpected sorts %v", sorts)
+	}
+	if b, _ := io.ReadAll(r.Body); len(b) == 0 {
+		t.Fatalf("body must be restored")
+	}
+	if path := NewPage[any](p, nil, 100).PathToPage(4); path != "/search?p=4&sort=name" {
+		t.Fatalf("unexpected path %q", path)
+	}
+
+	r = httptest.NewRequest("POST", "/search?page=2", strings.NewReader("size=5&sorts=-name,id"))
+	r.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	p, err = FromRequest(r, PagingConfig{SortPolicy: &SortPolicy{Fields: map[string]string{"name": ""}, Strict: true}})
+	if err == nil {
+		t.Fatalf("expected sort error")
+	}
+	if p.PageNumber() != 2 || p.PageSize() != 5 || len(p.PageSorts()) != 1 {
+		t.Fatalf("unexpected paging %+v", p.Paging)
+	}
+}
+
+func TestNewPagingWithConfig(t *testing.T) {
+	u, _ := url.Parse("/items?page=-1&size=30")
+	config := PagingConfig{DefaultSize: 10, AllowedSizes: []int{10, 25, 50}}
+	p, err := NewPagingWithConfig(u, config)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if p.PageNumber() != 1 || p.PageSize() != 25 {
+		t.Fatalf("unexpected paging %+v", p.Paging)
+	}
+
+	config.Strict = true
+	_, err = NewPagingWithConfig(u, config)
+	var paramErr *ParamError
+	if !errors.As(err, &paramErr) || !errors.Is(err, simplepage.ErrInvalidPageNumber) || !errors.Is(err, simplepage.ErrPageSizeNotAllowed) {
+		t.Fatalf("expected page and size errors, got %v", err)
+	}
+
+	u, _ = url.Parse("/items")
+	if p, _ := NewPagingWithConfig(u, config); p.PageSize() != 10 {
+		t.Fatalf("expected default size 10, got %d", p.PageSize())
+	}
+}
+
+func TestNewPagingWithConfigSortPolicy(t *testing.T) {
+	u, _ := url.Parse("/items?sorts=password,-name")
+	policy := &SortPolicy{Fields: map[string]string{"name": ""}, Default: NewSorts("id")}
+	p, err := NewPagingWithConfig(u, PagingConfig{SortPolicy: policy})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if sorts := p.PageSorts(); len(sorts) != 1 || sorts[0].String() != "-name" {
+		t.Fatalf("unexpected sorts %v", sorts)
+	}
+
+	policy.Strict = true
+	_, err = NewPagingWithConfig(u, PagingConfig{SortPolicy: policy})
+	var sortErr *SortError
+	if !errors.As(err, &sortErr) || sortErr.Field != "password" {
+		t.Fatalf("expected sort error, got %v", err)
+	}
+}
+
+func TestFromRequestBodyTooLarge(t *testing.T) {
+	body := `{"page": 2, "q": "` + strings.Repeat("a", maxJSONBodySize) + `"}`
+	r := httptest.NewRequest("POST", "/search", strings.NewReader(body))
+	r.Header.Set("Content-Type", "application/json")
+	p, err := FromRequest(r, PagingConfig{})
+	var paramErr *ParamError
+	if !errors.As(err, &paramErr) || !errors.Is(err, ErrBodyTooLarge) {
+		t.Fatalf("expected body too large error, got %v", err)
+	}
+	if p.PageNumber() != 1 {
+		t.Fatalf("unexpected paging %+v", p.Paging)
+	}
+	if b, _ := io.ReadAll(r.Body); string(b) != body {
+		t.Fatalf("body must be restored")
+	}
+}
+
+func TestFromRequestErrorKeepsConfig(t *testing.T) {
+	config := PagingConfig{
+		Params:       ParamNames{Page: "p", Size: "n"},
+		MaxSize:      20,
+		DefaultSorts: []string{"-id"},
+	}
+	r := httptest.NewRequest("POST", "/search?p=3&n=100", strings.NewReader(`{"p": `))
+	r.Header.Set("Content-Type", "application/json")
+	p, err := FromRequest(r, config)
+	if err == nil {
+		t.Fatalf("invalid JSON body must fail")
+	}
+	if p.PageNumber() != 3 || p.PageSize() != 20 || p.Params().Page != "p" || p.PageSorts()[0].String() != "-id" {
+		t.Fatalf("config must be kept, got %+v", p)
+	}
+	if got := NewPage[int](p, nil, 100).PathToPage(2); got != "/search?n=100&p=2" {
+		t.Fatalf("unexpected page link %q", got)
+	}
+}
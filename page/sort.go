@@ -6,6 +6,8 @@
 
 type Sorts = simplepage.Sorts
 type Sort = simplepage.Sort
+type SortPolicy = simplepage.SortPolicy
+type SortError = simplepage.SortError
 
 // NewSorts create a list of [Sort] from a list of string.
 // String starting with a negative sign '-' indicate desc sort.
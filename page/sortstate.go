@@ -0,0 +1,84 @@
+package page
+
+// SortDirection is the sort direction of a field.
+type SortDirection string
+
+const (
+	SortNone SortDirection = "none"
+	SortAsc  SortDirection = "asc"
+	SortDesc SortDirection = "desc"
+)
+
+// SortState is the sort state of a field in the current page, used for rendering sortable table headers.
+type SortState struct {
+	Field     string
+	Direction SortDirection
+	// Position the 1-based position of the field in the sorts, 0 if the field is not sorted.
+	Position int
+}
+
+// IsSorted return whether the field is sorted.
+func (s SortState) IsSorted() bool {
+	return s.Position > 0
+}
+
+// IsAsc return whether the field is sorted ascending.
+func (s SortState) IsAsc() bool {
+	return s.Direction == SortAsc
+}
+
+// IsDesc return whether the field is sorted descending.
+func (s SortState) IsDesc() bool {
+	return s.Direction == SortDesc
+}
+
+// SortState returns the sort state of the field.
+func (p Page[T]) SortState(field string) SortState {
+	for i, sort := range p.Sorts {
+		if sort.Field != field {
+			continue
+		}
+		state := SortState{Field: field, Direction: SortAsc, Position: i + 1}
+		if sort.IsDesc {
+			state.Direction = SortDesc
+		}
+		return state
+	}
+	return SortState{Field: field, Direction: SortNone}
+}
+
+// PathToggleSort returns the URL path for sorting by only the given field,
+// cycling through asc → desc → none of its current state.
+// Changing sorts will reset the page to 1.
+func (p Page[T]) PathToggleSort(field string) string {
+	switch p.SortState(field).Direction {
+	case SortNone:
+		return p.PathToSort(field)
+	case SortAsc:
+		return p.PathToSort(Sort{Field: field, IsDesc: true}.String())
+	default:
+		return p.PathToSort()
+	}
+}
+
+// PathAddSort returns the URL path for multi-column sort,
+// which keeps other sorts and cycles the given field through asc → desc → none.
+// The field is appended to the sorts if it is not sorted yet.
+// Changing sorts will reset the page to 1.
+func (p Page[T]) PathAddSort(field string) string {
+	state := p.SortState(field)
+	sorts := make([]string, 0, len(p.Sorts)+1)
+	for _, sort := range p.Sorts {
+		if sort.Field != field {
+			sorts = append(sorts, sort.String())
+			continue
+		}
+		if state.Direction == SortAsc {
+			sorts = append(sorts, Sort{Field: field, IsDesc: true}.String())
+		}
+	}
+	if state.Direction == SortNone {
+		sorts = append(sorts, field)
+	}
+	return p.PathToSort(sorts...)
+}
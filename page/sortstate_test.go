@@ -0,0 +1,35 @@
+package page
+
+import (
+	"net/url"
+	"testing"
+)
+
+func TestSortState(t *testing.T) {
+	u, _ := url.Parse("/items?page=3&q=go&sorts=-name,id")
+	p := NewPage[any](NewPaging(u), nil, 100)
+
+	if state := p.SortState("name"); !state.IsDesc() || state.Position != 1 {
+		t.Fatalf("unexpected name state %+v", state)
+	}
+	if state := p.SortState("id"); !state.IsAsc() || state.Position != 2 {
+		t.Fatalf("unexpected id state %+v", state)
+	}
+	if state := p.SortState("age"); state.IsSorted() || state.Direction != SortNone {
+		t.Fatalf("unexpected age state %+v", state)
+	}
+
+	cases := [][2]string{
+		{p.PathToggleSort("age"), "/items?q=go&sorts=age"},
+		{p.PathToggleSort("id"), "/items?q=go&sorts=-id"},
+		{p.PathToggleSort("name"), "/items?q=go"},
+		{p.PathAddSort("age"), "/items?q=go&sorts=-name&sorts=id&sorts=age"},
+		{p.PathAddSort("id"), "/items?q=go&sorts=-name&sorts=-id"},
+		{p.PathAddSort("name"), "/items?q=go&sorts=id"},
+	}
+	for _, c := range cases {
+		if got, expected := c[0], c[1]; got != expected {
+			t.Fatalf("expected %q, got %q", expected, got)
+		}
+	}
+}
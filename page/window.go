@@ -0,0 +1,56 @@
+package page
+
+// PageLink is a link to a page, used for rendering numbered pagination.
+type PageLink struct {
+	Number     int    `json:"number,omitempty"`
+	Path       string `json:"path,omitempty"`
+	IsCurrent  bool   `json:"isCurrent,omitempty"`
+	IsEllipsis bool   `json:"isEllipsis,omitempty"`
+}
+
+// PageWindow return the links of the first page, the last page
+// and n pages on each side of the current page.
+// Gaps between them are represented by an ellipsis link.
+//
+// For example, PageWindow(2) on page 6 of 20 returns: 1 … 4 5 [6] 7 8 … 20.
+func (p Page[T]) PageWindow(n int) []PageLink {
+	current := p.CurrentPage()
+	last := max(p.TotalPages, current)
+	n = max(n, 0)
+
+	links := make([]PageLink, 0, 2*n+5)
+	from := max(current-n, 1)
+	to := min(current+n, last)
+	// Show the page instead of an ellipsis that only hides a single page.
+	if from == 3 {
+		from = 2
+	}
+	if to == last-2 {
+		to = last - 1
+	}
+
+	if from > 1 {
+		links = append(links, p.pageLink(1))
+		if from > 2 {
+			links = append(links, PageLink{IsEllipsis: true})
+		}
+	}
+	for i := from; i <= to; i++ {
+		links = append(links, p.pageLink(i))
+	}
+	if to < last {
+		if to < last-1 {
+			links = append(links, PageLink{IsEllipsis: true})
+		}
+		links = append(links, p.pageLink(last))
+	}
+	return links
+}
+
+func (p Page[T]) pageLink(number int) PageLink {
+	return PageLink{
+		Number:    number,
+		Path:      p.PathToPage(number),
+		IsCurrent: number == p.CurrentPage(),
+	}
+}
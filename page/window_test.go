@@ -0,0 +1,42 @@
+package page
+
+import (
+	"net/url"
+	"strconv"
+	"strings"
+	"testing"
+)
+
+func TestPageWindow(t *testing.T) {
+	cases := []struct {
+		page     int
+		total    int64
+		expected string
+	}{
+		{page: 6, total: 20, expected: "1 … 4 5 [6] 7 8 … 20"},
+		{page: 1, total: 20, expected: "[1] 2 3 … 20"},
+		{page: 5, total: 20, expected: "1 2 3 4 [5] 6 7 … 20"},
+		{page: 20, total: 20, expected: "1 … 18 19 [20]"},
+		{page: 2, total: 4, expected: "1 [2] 3 4"},
+		{page: 1, total: 0, expected: "[1]"},
+	}
+	for _, c := range cases {
+		u, _ := url.Parse("/items?page=" + strconv.Itoa(c.page) + "&size=1")
+		p := NewPage[any](NewPaging(u), nil, c.total)
+
+		labels := make([]string, 0, 10)
+		for _, link := range p.PageWindow(2) {
+			switch {
+			case link.IsEllipsis:
+				labels = append(labels, "…")
+			case link.IsCurrent:
+				labels = append(labels, "["+strconv.Itoa(link.Number)+"]")
+			default:
+				labels = append(labels, strconv.Itoa(link.Number))
+			}
+		}
+		if got := strings.Join(labels, " "); got != c.expected {
+			t.Fatalf("page %d of %d: expected %q, got %q", c.page, c.total, c.expected, got)
+		}
+	}
+}
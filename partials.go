@@ -0,0 +1,136 @@
+package tmpls
+
+import (
+	"embed"
+	"github.com/mawngo/go-tmpls/v2/page"
+	"io/fs"
+	"strings"
+)
+
+//go:embed all:partials
+var partialsFS embed.FS
+
+// PageStyle is the CSS class set used by the built-in page partials.
+type PageStyle string
+
+const (
+	// PageStylePlain uses unopinionated class names, for styling by your own CSS.
+	PageStylePlain PageStyle = "plain"
+	// PageStyleBulma uses Bulma classes.
+	PageStyleBulma PageStyle = "bulma"
+	// PageStyleBootstrap uses Bootstrap 5 classes.
+	PageStyleBootstrap PageStyle = "bootstrap"
+	// PageStyleTailwind uses Tailwind CSS utility classes.
+	PageStyleTailwind PageStyle = "tailwind"
+)
+
+// pageClasses is the class set of each style, by class key.
+var pageClasses = map[PageStyle]map[string]string{
+	PageStylePlain: {
+		"nav":          "pagination",
+		"list":         "pagination-list",
+		"link":         "pagination-link",
+		"linkCurrent":  "is-current",
+		"linkDisabled": "is-disabled",
+		"ellipsis":     "pagination-ellipsis",
+		"sort":         "sort-link",
+		"sortActive":   "is-active",
+		"sizes":        "page-sizes",
+		"size":         "page-size",
+		"sizeCurrent":  "is-current",
+		"search":       "search",
+		"searchInput":  "search-input",
+		"searchButton": "search-button",
+	},
+	PageStyleBulma: {
+		"nav":          "pagination is-centered",
+		"list":         "pagination-list",
+		"link":         "pagination-link",
+		"linkCurrent":  "is-current",
+		"linkDisabled": "is-disabled",
+		"ellipsis":     "pagination-ellipsis",
+		"sort":         "has-text-inherit",
+		"sortActive":   "has-text-weight-bold",
+		"sizes":        "buttons has-addons",
+		"size":         "button is-small",
+		"sizeCurrent":  "is-selected is-link",
+		"search":       "field has-addons",
+		"searchInput":  "input",
+		"searchButton": "button",
+	},
+	PageStyleBootstrap: {
+		"nav":          "d-flex justify-content-center",
+		"list":         "pagination",
+		"item":         "page-item",
+		"itemCurrent":  "active",
+		"itemDisabled": "disabled",
+		"link":         "page-link",
+		"ellipsis":     "page-link",
+		"sort":         "link-body-emphasis text-decoration-none",
+		"sortActive":   "fw-bold",
+		"sizes":        "btn-group btn-group-sm",
+		"size":         "btn btn-outline-secondary",
+		"sizeCurrent":  "active",
+		"search":       "input-group",
+		"searchInput":  "form-control",
+		"searchButton": "btn btn-outline-secondary",
+	},
+	PageStyleTailwind: {
+		"nav":          "flex justify-center",
+		"list":         "inline-flex -space-x-px text-sm",
+		"link":         "flex items-center px-3 h-8 border border-gray-300 text-gray-600 hover:bg-gray-100",
+		"linkCurrent":  "bg-blue-50 text-blue-600",
+		"linkDisabled": "text-gray-300 pointer-events-none",
+		"ellipsis":     "flex items-center px-3 h-8 border border-gray-300 text-gray-400",
+		"sort":         "inline-flex items-center gap-1 hover:underline",
+		"sortActive":   "font-semibold",
+		"sizes":        "inline-flex gap-1 text-sm",
+		"size":         "px-2 py-1 rounded border border-gray-300 hover:bg-gray-100",
+		"sizeCurrent":  "bg-blue-50 text-blue-600",
+		"search":       "flex gap-2",
+		"searchInput":  "flex-1 px-3 py-1 rounded border border-gray-300",
+		"searchButton": "px-3 py-1 rounded bg-blue-600 text-white",
+	},
+}
+
+// WithPagePartials mount the built-in page partials, using the class set of the given style.
+// The page template functions ([page.FuncMap]) are also added, and are kept when [WithFuncs] is used,
+// with functions of the same name set by WithFuncs taking precedence.
+//
+// The partials are named as _page.(name) (using the configured separator), and can be overridden by
+// templates of the same name:
+//   - _page.paginator: paginator with page window, the data must be a [page.Page].
+//   - _page.sort: sortable column header (th) with link, the data must be a dict of Page, Field and Label.
+//   - _page.size: page-size selector, the data must be a [page.Page].
+//   - _page.search: search box, the data must be a [page.Page].
+func WithPagePartials(style PageStyle) TemplatesOption {
+	sub, err := fs.Sub(partialsFS, "partials")
+	if err != nil {
+		panic(err)
+	}
+	classes, ok := pageClasses[style]
+	if !ok {
+		classes = pageClasses[PageStylePlain]
+	}
+
+	funcs := FuncMap(page.FuncMap())
+	// pageClass returns the classes of the given keys, separated by space.
+	funcs["pageClass"] = func(keys ...string) string {
+		res := make([]string, 0, len(keys))
+		for _, key := range keys {
+			if class := classes[key]; class != "" {
+				res = append(res, class)
+			}
+		}
+		return strings.Join(res, " ")
+	}
+	return func(options *templatesOptions) {
+		if options.mountFuncs == nil {
+			options.mountFuncs = make(FuncMap, len(funcs))
+		}
+		for name, fn := range funcs {
+			options.mountFuncs[name] = fn
+		}
+		options.mounts = append(options.mounts, mount{fs: sub, extensions: []string{".gohtml"}})
+	}
+}
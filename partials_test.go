@@ -0,0 +1,99 @@
+package tmpls
+
+import (
+	"github.com/mawngo/go-tmpls/v2/page"
+	"net/url"
+	"strings"
+	"testing"
+	"testing/fstest"
+)
+
+func TestPagePartials(t *testing.T) {
+	fsys := fstest.MapFS{
+		"index.gohtml":      {Data: []byte(`{{ template "_page.paginator" .Page }}|{{ template "_page.sort" dict "Page" .Page "Field" "name" "Label" "Name" }}|{{ template "_page.search" .Page }}`)},
+		"_page/size.gohtml": {Data: []byte(`custom size`)},
+		"sizes.gohtml":      {Data: []byte(`{{ template "_page.size" . }}`)},
+	}
+	templates, err := New(fsys, WithPagePartials(PageStyleBootstrap))
+	if err != nil {
+		t.Fatalf("new templates: %v", err)
+	}
+
+	u, _ := url.Parse("/items?page=2&size=10&sorts=name&sorts=-id&q=abc")
+	p := page.NewPage[int](page.NewPaging(u), make([]int, 10), 100)
+	var sb strings.Builder
+	if err := templates.ExecuteTemplate(&sb, "index", map[string]any{"Page": p}); err != nil {
+		t.Fatalf("execute: %v", err)
+	}
+	out := sb.String()
+	for _, want := range []string{
+		`class="page-item active"`,
+		`aria-current="page">2</a>`,
+		`<th aria-sort="ascending"><a`,
+		`<nav class="d-flex justify-content-center"`,
+		`class="link-body-emphasis text-decoration-none fw-bold"`,
+		`name="q" value="abc"`,
+		`<input type="hidden" name="sorts" value="name"><input type="hidden" name="sorts" value="-id">`,
+	} {
+		if !strings.Contains(out, want) {
+			t.Fatalf("output missing %q: %s", want, out)
+		}
+	}
+
+	sb.Reset()
+	if err := templates.ExecuteTemplate(&sb, "sizes", p); err != nil {
+		t.Fatalf("execute: %v", err)
+	}
+	if sb.String() != "custom size" {
+		t.Fatalf("partial not overridden: %s", sb.String())
+	}
+}
+
+func TestPagePartialsWithFuncs(t *testing.T) {
+	fsys := fstest.MapFS{
+		"index.gohtml": {Data: []byte(`{{ greet }}|{{ template "_page.search" .Page }}`)},
+	}
+	templates, err := New(fsys,
+		WithFuncs(FuncMap{"greet": func() string { return "unused" }}),
+		WithPagePartials(PageStylePlain),
+		WithFuncs(FuncMap{"greet": func() string { return "hello" }}),
+	)
+	if err != nil {
+		t.Fatalf("new templates: %v", err)
+	}
+
+	u, _ := url.Parse("/items?q=abc")
+	p := page.NewPage[int](page.NewPaging(u), nil, 0)
+	var sb strings.Builder
+	if err := templates.ExecuteTemplate(&sb, "index", map[string]any{"Page": p}); err != nil {
+		t.Fatalf("execute: %v", err)
+	}
+	if out := sb.String(); !strings.HasPrefix(out, "hello|") || !strings.Contains(out, `value="abc"`) {
+		t.Fatalf("unexpected output: %s", out)
+	}
+}
+
+func TestPagePartialsSizes(t *testing.T) {
+	fsys := fstest.MapFS{
+		"index.gohtml": {Data: []byte(`{{ template "_page.size" . }}`)},
+	}
+	templates, err := New(fsys, WithPagePartials(PageStylePlain))
+	if err != nil {
+		t.Fatalf("new templates: %v", err)
+	}
+
+	u, _ := url.Parse("/items?size=30")
+	paging, err := page.NewPagingWithConfig(u, page.PagingConfig{AllowedSizes: []int{15, 30}})
+	if err != nil {
+		t.Fatalf("new paging: %v", err)
+	}
+	var sb strings.Builder
+	if err := templates.ExecuteTemplate(&sb, "index", page.NewPage[int](paging, nil, 0)); err != nil {
+		t.Fatalf("execute: %v", err)
+	}
+	out := sb.String()
+	if !strings.Contains(out, `href="/items?size=15">15</a>`) || !strings.Contains(out, `aria-current="true">30</a>`) ||
+		strings.Contains(out, ">50<") {
+		t.Fatalf("unexpected sizes: %s", out)
+	}
+}
@@ -0,0 +1,220 @@
+package simplepage
+
+import (
+	"bytes"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+)
+
+var (
+	// ErrInvalidCursor is returned when decoding a malformed cursor.
+	ErrInvalidCursor = errors.New("invalid cursor")
+	// ErrCursorSignature is returned when the cursor signature is missing or does not match.
+	ErrCursorSignature = errors.New("invalid cursor signature")
+	// ErrCursorSorts is returned when the cursor was created for different sorts.
+	ErrCursorSorts = errors.New("cursor sorts mismatch")
+)
+
+// Cursor is the position of keyset (seek) pagination.
+// It holds the sort values of the boundary item of a page, in the same order as [Cursor.Sorts].
+type Cursor struct {
+	// Sorts the sorts that the cursor was created with.
+	Sorts Sorts
+	// Values of the sort fields of the boundary item.
+	Values []any
+	// IsPrevious whether the cursor points to the items before the boundary item,
+	// instead of the items after it.
+	IsPrevious bool
+}
+
+// CursorKey is a single keyset comparison of a [Cursor].
+type CursorKey struct {
+	Field string
+	Value any
+	// IsGreater whether the requested items have values greater than Value on this field.
+	// Otherwise, the requested items have values less than Value.
+	IsGreater bool
+}
+
+// NewCursor create a [Cursor] pointing to the items after the given item.
+// Values of the sort fields are read from item, which can be a struct or a map with string keys.
+// Struct fields are matched by name, then by json tag, then by name case-insensitively.
+//
+// Use [NewCursorFunc] to avoid reflection.
+func NewCursor(item any, sorts Sorts) (Cursor, error) {
+	values := make([]any, 0, len(sorts))
+	for _, sort := range sorts {
+		value, ok := fieldValue(item, sort.Field)
+		if !ok {
+			return Cursor{}, fmt.Errorf("cursor field %q not found", sort.Field)
+		}
+		values = append(values, value)
+	}
+	return Cursor{Sorts: sorts, Values: values}, nil
+}
+
+// NewCursorFunc create a [Cursor] pointing to the items after the given item,
+// using value to read the sort fields of the item.
+func NewCursorFunc[T any](item T, sorts Sorts, value func(item T, field string) any) Cursor {
+	values := make([]any, 0, len(sorts))
+	for _, sort := range sorts {
+		values = append(values, value(item, sort.Field))
+	}
+	return Cursor{Sorts: sorts, Values: values}
+}
+
+// Previous return a copy of the cursor that points to the items before the boundary item.
+func (c Cursor) Previous() Cursor {
+	c.IsPrevious = true
+	return c
+}
+
+// Keys return the keyset comparisons of this cursor, in the order of [Cursor.Sorts].
+func (c Cursor) Keys() []CursorKey {
+	keys := make([]CursorKey, 0, len(c.Sorts))
+	for i, sort := range c.Sorts {
+		var value any
+		if i < len(c.Values) {
+			value = c.Values[i]
+		}
+		keys = append(keys, CursorKey{
+			Field:     sort.Field,
+			Value:     value,
+			IsGreater: sort.IsDesc == c.IsPrevious,
+		})
+	}
+	return keys
+}
+
+// QuerySorts return the sorts to query items with.
+// When the cursor points to the previous items, the sorts are reversed,
+// and the queried items must be reversed back to the original order.
+func (c Cursor) QuerySorts() Sorts {
+	if !c.IsPrevious {
+		return c.Sorts
+	}
+	sorts := make(Sorts, 0, len(c.Sorts))
+	for _, sort := range c.Sorts {
+		sorts = append(sorts, Sort{Field: sort.Field, IsDesc: !sort.IsDesc})
+	}
+	return sorts
+}
+
+// cursorPayload is the serialized form of [Cursor].
+type cursorPayload struct {
+	Sorts      []string `json:"s"`
+	Values     []any    `json:"v"`
+	IsPrevious bool     `json:"p,omitempty"`
+}
+
+// CursorCodec encode and decode [Cursor] to opaque string.
+//
+// The cursor is encoded as base64url JSON, optionally signed using HMAC-SHA256.
+// As the values are decoded from JSON, numbers are decoded as [json.Number]
+// and [time.Time] are decoded as RFC 3339 string.
+type CursorCodec struct {
+	secret []byte
+}
+
+// NewCursorCodec create a [CursorCodec].
+// If secret is empty, the encoded cursors are not signed.
+func NewCursorCodec(secret []byte) CursorCodec {
+	return CursorCodec{secret: secret}
+}
+
+// Encode return the opaque string of the cursor.
+func (c CursorCodec) Encode(cursor Cursor) (string, error) {
+	payload := cursorPayload{
+		Sorts:      make([]string, 0, len(cursor.Sorts)),
+		Values:     make([]any, 0, len(cursor.Values)),
+		IsPrevious: cursor.IsPrevious,
+	}
+	for _, sort := range cursor.Sorts {
+		payload.Sorts = append(payload.Sorts, sort.String())
+	}
+	for _, value := range cursor.Values {
+		if t, ok := value.(time.Time); ok {
+			value = t.Format(time.RFC3339Nano)
+		}
+		payload.Values = append(payload.Values, value)
+	}
+
+	b, err := json.Marshal(payload)
+	if err != nil {
+		return "", err
+	}
+	encoded := base64.RawURLEncoding.EncodeToString(b)
+	if len(c.secret) == 0 {
+		return encoded, nil
+	}
+	return encoded + "." + base64.RawURLEncoding.EncodeToString(c.sign(encoded)), nil
+}
+
+// Decode parse the opaque cursor string.
+// The decoded cursor must be created with the given sorts, otherwise [ErrCursorSorts] is returned.
+// If sorts is nil, the sorts of the cursor are not checked.
+func (c CursorCodec) Decode(raw string, sorts Sorts) (Cursor, error) {
+	encoded, signature, signed := strings.Cut(raw, ".")
+	if len(c.secret) > 0 {
+		if !signed {
+			return Cursor{}, ErrCursorSignature
+		}
+		sig, err := base64.RawURLEncoding.DecodeString(signature)
+		if err != nil || !hmac.Equal(sig, c.sign(encoded)) {
+			return Cursor{}, ErrCursorSignature
+		}
+	} else if signed {
+		return Cursor{}, ErrInvalidCursor
+	}
+
+	b, err := base64.RawURLEncoding.DecodeString(encoded)
+	if err != nil {
+		return Cursor{}, fmt.Errorf("%w: %w", ErrInvalidCursor, err)
+	}
+	decoder := json.NewDecoder(bytes.NewReader(b))
+	decoder.UseNumber()
+	var payload cursorPayload
+	if err := decoder.Decode(&payload); err != nil {
+		return Cursor{}, fmt.Errorf("%w: %w", ErrInvalidCursor, err)
+	}
+	if len(payload.Sorts) != len(payload.Values) {
+		return Cursor{}, ErrInvalidCursor
+	}
+
+	cursor := Cursor{
+		Sorts:      NewSorts(payload.Sorts),
+		Values:     payload.Values,
+		IsPrevious: payload.IsPrevious,
+	}
+	if len(cursor.Sorts) != len(payload.Sorts) {
+		return Cursor{}, ErrInvalidCursor
+	}
+	if sorts != nil && !equalSorts(cursor.Sorts, sorts) {
+		return Cursor{}, ErrCursorSorts
+	}
+	return cursor, nil
+}
+
+func (c CursorCodec) sign(encoded string) []byte {
+	mac := hmac.New(sha256.New, c.secret)
+	mac.Write([]byte(encoded))
+	return mac.Sum(nil)
+}
+
+func equalSorts(a, b Sorts) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
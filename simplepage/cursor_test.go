@@ -0,0 +1,53 @@
+package simplepage
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+)
+
+func TestCursorCodec(t *testing.T) {
+	type item struct {
+		ID   int64  `json:"id"`
+		Name string `json:"name"`
+	}
+	sorts := NewSorts([]string{"-name", "id"})
+	cursor, err := NewCursor(item{ID: 10, Name: "Bob"}, sorts)
+	if err != nil {
+		t.Fatalf("NewCursor: %v", err)
+	}
+
+	codec := NewCursorCodec([]byte("secret"))
+	raw, err := codec.Encode(cursor.Previous())
+	if err != nil {
+		t.Fatalf("Encode: %v", err)
+	}
+	decoded, err := codec.Decode(raw, sorts)
+	if err != nil {
+		t.Fatalf("Decode: %v", err)
+	}
+	if !decoded.IsPrevious {
+		t.Fatalf("decoded cursor must be previous")
+	}
+	if decoded.Values[0] != "Bob" || decoded.Values[1] != json.Number("10") {
+		t.Fatalf("unexpected values %v", decoded.Values)
+	}
+
+	keys := decoded.Keys()
+	if keys[0].Field != "name" || !keys[0].IsGreater {
+		t.Fatalf("previous of desc sort must compare greater")
+	}
+	if keys[1].Field != "id" || keys[1].IsGreater {
+		t.Fatalf("previous of asc sort must compare less")
+	}
+
+	if _, err := codec.Decode(raw, NewSorts([]string{"name", "id"})); !errors.Is(err, ErrCursorSorts) {
+		t.Fatalf("expected ErrCursorSorts, got %v", err)
+	}
+	if _, err := NewCursorCodec([]byte("other")).Decode(raw, sorts); !errors.Is(err, ErrCursorSignature) {
+		t.Fatalf("expected ErrCursorSignature, got %v", err)
+	}
+	if _, err := NewCursorCodec(nil).Decode(raw, sorts); !errors.Is(err, ErrInvalidCursor) {
+		t.Fatalf("expected ErrInvalidCursor, got %v", err)
+	}
+}
@@ -0,0 +1,195 @@
+package simplepage
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// CountFunc count the total items.
+type CountFunc func(ctx context.Context) (int64, error)
+
+// ItemsFunc fetch the items requested by the pageable.
+type ItemsFunc[T any] func(ctx context.Context, pageable Pageable) ([]T, error)
+
+// CountCache caches the total items count between [Fetch] calls.
+type CountCache interface {
+	Get(key string) (int64, bool)
+	Set(key string, count int64)
+}
+
+// FetchOption is the option for [Fetch].
+type FetchOption func(*fetchOptions)
+
+type fetchOptions struct {
+	cache      CountCache
+	cacheKey   string
+	countLimit int64
+	nocount    bool
+}
+
+// WithCountCache use the cache for the total items count, stored under the given key.
+// The key must identify the counted query, including its filters.
+func WithCountCache(cache CountCache, key string) FetchOption {
+	return func(options *fetchOptions) {
+		options.cache = cache
+		options.cacheKey = key
+	}
+}
+
+// WithCountLimit cap the total items count, so the page can be displayed as "1000+".
+// The result page is marked by [Page.IsTotalCapped] when the count exceed the limit.
+//
+// The count function should also limit the counting for better performance,
+// for example, by counting on a sub-query with LIMIT limit+1.
+func WithCountLimit(limit int64) FetchOption {
+	return func(options *fetchOptions) {
+		options.countLimit = limit
+	}
+}
+
+// WithoutCount disable counting, so [Fetch] returns a [Slice].
+func WithoutCount() FetchOption {
+	return func(options *fetchOptions) {
+		options.nocount = true
+	}
+}
+
+// Fetch build a [Page] by running the count and items functions concurrently.
+//
+// The count is skipped (and canceled if it is running) when it can be computed from the items,
+// which is when the page is not full, or the pageable is unpaged.
+//
+// If counting is disabled by [WithoutCount] or count is nil, a [Slice] is returned instead.
+// In that case, the items function can return one more item than the page size to indicate there is a next page,
+// otherwise a full page is considered to have a next page.
+func Fetch[T any](ctx context.Context, pageable Pageable, count CountFunc, items ItemsFunc[T], options ...FetchOption) (Paged[T], error) {
+	opt := fetchOptions{}
+	for _, option := range options {
+		option(&opt)
+	}
+	if opt.nocount || count == nil {
+		return fetchSlice(ctx, pageable, items)
+	}
+
+	var total int64
+	cached := false
+	if opt.cache != nil {
+		total, cached = opt.cache.Get(opt.cacheKey)
+	}
+
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	type countResult struct {
+		total int64
+		err   error
+	}
+	var counted chan countResult
+	if !cached && !pageable.IsUnpaged() {
+		// Buffered, so the goroutine can exit when the result is not needed.
+		counted = make(chan countResult, 1)
+		go func() {
+			total, err := count(ctx)
+			counted <- countResult{total: total, err: err}
+		}()
+	}
+
+	list, err := items(ctx, pageable)
+	if err != nil {
+		return nil, err
+	}
+
+	size := len(list)
+	switch {
+	case pageable.IsUnpaged():
+		total = int64(size)
+	case size < pageable.PageSize() && (size > 0 || pageable.PageNumber() == DefaultPageNumber):
+		// Not a full page, so this is the last page.
+		cancel()
+		total = pageable.PageOffset() + int64(size)
+		if opt.cache != nil {
+			opt.cache.Set(opt.cacheKey, total)
+		}
+	case counted != nil:
+		select {
+		case r := <-counted:
+			if r.err != nil {
+				return nil, r.err
+			}
+			total = r.total
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		}
+		if opt.cache != nil {
+			opt.cache.Set(opt.cacheKey, total)
+		}
+	}
+
+	capped := opt.countLimit > 0 && total > opt.countLimit
+	if capped {
+		total = opt.countLimit
+	}
+	page := NewPage(pageable, list, total)
+	if capped {
+		page.IsTotalCapped = true
+		page.HasNext = page.HasNext || size >= page.PageSize
+	}
+	return page, nil
+}
+
+func fetchSlice[T any](ctx context.Context, pageable Pageable, items ItemsFunc[T]) (Paged[T], error) {
+	list, err := items(ctx, pageable)
+	if err != nil {
+		return nil, err
+	}
+	if pageable.IsUnpaged() {
+		return NewSlice(pageable, list, false), nil
+	}
+	size := pageable.PageSize()
+	if len(list) > size {
+		return NewSlice(pageable, list[:size], true), nil
+	}
+	return NewSlice(pageable, list, len(list) == size), nil
+}
+
+// countCache is the in-memory [CountCache].
+type countCache struct {
+	mu      sync.Mutex
+	ttl     time.Duration
+	entries map[string]countEntry
+}
+
+type countEntry struct {
+	count     int64
+	expiresAt time.Time
+}
+
+// NewCountCache create an in-memory [CountCache], which entries expire after the ttl.
+// Expired entries are only removed when read, so the cardinality of the keys should be bounded.
+func NewCountCache(ttl time.Duration) CountCache {
+	return &countCache{
+		ttl:     ttl,
+		entries: make(map[string]countEntry),
+	}
+}
+
+func (c *countCache) Get(key string) (int64, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	entry, ok := c.entries[key]
+	if !ok {
+		return 0, false
+	}
+	if time.Now().After(entry.expiresAt) {
+		delete(c.entries, key)
+		return 0, false
+	}
+	return entry.count, true
+}
+
+func (c *countCache) Set(key string, count int64) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.entries[key] = countEntry{count: count, expiresAt: time.Now().Add(c.ttl)}
+}
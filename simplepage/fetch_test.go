@@ -0,0 +1,62 @@
+package simplepage
+
+import (
+	"context"
+	"testing"
+	"time"
+)
+
+func TestFetch(t *testing.T) {
+	all := make([]int, 0, 25)
+	for i := range 25 {
+		all = append(all, i)
+	}
+	counted := 0
+	count := func(_ context.Context) (int64, error) {
+		counted++
+		return int64(len(all)), nil
+	}
+	items := func(_ context.Context, pageable Pageable) ([]int, error) {
+		return PageSlice(pageable, all).Items, nil
+	}
+
+	p, err := Fetch(context.Background(), NewPaging(2, 10), count, items)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if page := p.(Page[int]); page.TotalItems != 25 || page.TotalPages != 3 || len(page.Items) != 10 {
+		t.Fatalf("unexpected page %+v", page)
+	}
+
+	p, err = Fetch(context.Background(), NewPaging(1, 50), func(ctx context.Context) (int64, error) {
+		<-ctx.Done()
+		return 0, ctx.Err()
+	}, items)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if page := p.(Page[int]); page.TotalItems != 25 || page.HasNext {
+		t.Fatalf("total must be computed from the short page %+v", page)
+	}
+
+	counted = 0
+	cache := NewCountCache(time.Minute)
+	for range 2 {
+		if _, err := Fetch(context.Background(), NewPaging(1, 10), count, items, WithCountCache(cache, "all")); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if counted != 1 {
+		t.Fatalf("count must be cached, counted %d times", counted)
+	}
+
+	p, _ = Fetch(context.Background(), NewPaging(2, 10), count, items, WithCountLimit(20))
+	if page := p.(Page[int]); !page.IsTotalCapped || page.TotalItems != 20 || !page.HasNext {
+		t.Fatalf("unexpected capped page %+v", page)
+	}
+
+	p, _ = Fetch(context.Background(), NewPaging(3, 10), count, items, WithoutCount())
+	if slice := p.(Slice[int]); slice.HasNext || len(slice.Items) != 5 {
+		t.Fatalf("unexpected slice %+v", slice)
+	}
+}
@@ -0,0 +1,60 @@
+package simplepage
+
+import (
+	"reflect"
+	"strings"
+)
+
+// fieldValue return the value of the given field of v.
+// v can be a struct, a map with string keys, or pointers to them.
+//
+// Struct fields are matched by name, then by json tag, then by name case-insensitively.
+func fieldValue(v any, field string) (any, bool) {
+	rv := reflect.ValueOf(v)
+	for rv.Kind() == reflect.Pointer || rv.Kind() == reflect.Interface {
+		if rv.IsNil() {
+			return nil, false
+		}
+		rv = rv.Elem()
+	}
+
+	switch rv.Kind() {
+	case reflect.Map:
+		if rv.Type().Key().Kind() != reflect.String {
+			return nil, false
+		}
+		value := rv.MapIndex(reflect.ValueOf(field).Convert(rv.Type().Key()))
+		if !value.IsValid() {
+			return nil, false
+		}
+		return value.Interface(), true
+	case reflect.Struct:
+		rt := rv.Type()
+		if f, ok := rt.FieldByName(field); ok && f.IsExported() {
+			return fieldByIndex(rv, f.Index)
+		}
+		for i := 0; i < rt.NumField(); i++ {
+			f := rt.Field(i)
+			if !f.IsExported() {
+				continue
+			}
+			if name, _, _ := strings.Cut(f.Tag.Get("json"), ","); name == field {
+				return rv.Field(i).Interface(), true
+			}
+		}
+		if f, ok := rt.FieldByNameFunc(func(name string) bool { return strings.EqualFold(name, field) }); ok && f.IsExported() {
+			return fieldByIndex(rv, f.Index)
+		}
+	default:
+	}
+	return nil, false
+}
+
+// fieldByIndex return the nested field value, or false if it is behind a nil embedded pointer.
+func fieldByIndex(rv reflect.Value, index []int) (any, bool) {
+	value, err := rv.FieldByIndexErr(index)
+	if err != nil {
+		return nil, false
+	}
+	return value.Interface(), true
+}
@@ -0,0 +1,198 @@
+package simplepage
+
+import (
+	"context"
+	"iter"
+)
+
+// NextPageable is implemented by pages that know how to request their next page,
+// such as cursor-based results, or pages that keep request state.
+// See [Pages].
+type NextPageable interface {
+	// NextPageable return the pageable of the next page, or false if there is no next page.
+	NextPageable() (Pageable, bool)
+}
+
+// PageSetter is implemented by pageables that can be copied with another page number,
+// keeping the other request state, such as the URL and filters of page.Paging.
+// Used by [Pages] for requesting the next page when the page does not implement [NextPageable].
+type PageSetter interface {
+	// WithPage return a copy of the pageable with the page number replaced.
+	WithPage(page int) Pageable
+}
+
+// sizePolicer is implemented by [Pageable] that has a [SizePolicy], see [Paging.PageSizePolicy].
+type sizePolicer interface {
+	PageSizePolicy() *SizePolicy
+}
+
+// FetchFunc fetch the page of items requested by the pageable.
+type FetchFunc[T any] func(ctx context.Context, pageable Pageable) (Paged[T], error)
+
+// IterOption is the option for [Pages] and [Items].
+type IterOption func(*iterOptions)
+
+type iterOptions struct {
+	prefetch bool
+}
+
+// WithPrefetch fetch the next page in the background while the current page is being consumed.
+func WithPrefetch() IterOption {
+	return func(options *iterOptions) {
+		options.prefetch = true
+	}
+}
+
+// Pages return an iterator over the pages fetched by fetch, starting from the given pageable,
+// until there is no next page.
+// The iteration stops at the first error, which is yielded with a nil page,
+// including the error of the context.
+//
+// Next page is requested with [Paging] of the next page number, keeping the size, sorts and size policy
+// of the requested pageable, unless the page implements [NextPageable],
+// which allows advancing through cursor-based results and keeping request state.
+// Whether there is a next page is decided by [Slice.HasNext] for [Slice] and [Page],
+// or by HasNext() bool if the page implements it, otherwise by whether the page is full.
+func Pages[T any](ctx context.Context, pageable Pageable, fetch FetchFunc[T], options ...IterOption) iter.Seq2[Paged[T], error] {
+	opt := iterOptions{}
+	for _, option := range options {
+		option(&opt)
+	}
+
+	type result struct {
+		page Paged[T]
+		err  error
+	}
+	return func(yield func(Paged[T], error) bool) {
+		ctx, cancel := context.WithCancel(ctx)
+		defer cancel()
+
+		fetchAsync := func(p Pageable) <-chan result {
+			// Buffered, so the goroutine can exit when the iteration is stopped early.
+			ch := make(chan result, 1)
+			go func() {
+				page, err := fetch(ctx, p)
+				ch <- result{page: page, err: err}
+			}()
+			return ch
+		}
+
+		next := pageable
+		var pending <-chan result
+		for {
+			if err := ctx.Err(); err != nil {
+				yield(nil, err)
+				return
+			}
+
+			var r result
+			if pending != nil {
+				r = <-pending
+				pending = nil
+			} else {
+				r.page, r.err = fetch(ctx, next)
+			}
+			if r.err != nil {
+				yield(nil, r.err)
+				return
+			}
+
+			nextPage, hasNext := nextPageable(r.page, next)
+			if hasNext && opt.prefetch {
+				pending = fetchAsync(nextPage)
+			}
+			if !yield(r.page, nil) || !hasNext {
+				return
+			}
+			next = nextPage
+		}
+	}
+}
+
+// Items return an iterator over all items of the pages fetched by fetch.
+// The iteration stops at the first error, which is yielded with a zero item.
+//
+// See [Pages] for how the pages are advanced.
+func Items[T any](ctx context.Context, pageable Pageable, fetch FetchFunc[T], options ...IterOption) iter.Seq2[T, error] {
+	return func(yield func(T, error) bool) {
+		for page, err := range Pages(ctx, pageable, fetch, options...) {
+			if err != nil {
+				var zero T
+				yield(zero, err)
+				return
+			}
+			for _, item := range page.GetItems() {
+				if !yield(item, nil) {
+					return
+				}
+			}
+		}
+	}
+}
+
+// PageSeq return an iterator over the pages like [Pages], for use where an [iter.Seq] is required.
+// The iteration stops at the first error, which is returned by the err function once the iteration ends.
+func PageSeq[T any](ctx context.Context, pageable Pageable, fetch FetchFunc[T], options ...IterOption) (seq iter.Seq[Paged[T]], err func() error) {
+	var iterErr error
+	seq = func(yield func(Paged[T]) bool) {
+		iterErr = nil
+		for page, err := range Pages(ctx, pageable, fetch, options...) {
+			if err != nil {
+				iterErr = err
+				return
+			}
+			if !yield(page) {
+				return
+			}
+		}
+	}
+	return seq, func() error {
+		return iterErr
+	}
+}
+
+// nextPageable return the pageable of the next page, or false if there is no next page.
+// The requested pageable is the one that the page was fetched with,
+// which is copied with the next page number if it implements [PageSetter].
+func nextPageable[T any](p Paged[T], requested Pageable) (Pageable, bool) {
+	if next, ok := p.(NextPageable); ok {
+		return next.NextPageable()
+	}
+
+	pageable := p.GetPageable()
+	if pageable.IsUnpaged() || p.IsEmpty() {
+		return nil, false
+	}
+	var hasNext bool
+	switch v := any(p).(type) {
+	case Slice[T]:
+		hasNext = v.HasNext
+	case *Slice[T]:
+		hasNext = v.HasNext
+	case Page[T]:
+		hasNext = v.HasNext
+	case *Page[T]:
+		hasNext = v.HasNext
+	default:
+		if next, ok := p.(hasNexter); ok {
+			hasNext = next.HasNext()
+		} else {
+			hasNext = len(p.GetItems()) >= pageable.PageSize()
+		}
+	}
+	if !hasNext {
+		return nil, false
+	}
+	if setter, ok := requested.(PageSetter); ok {
+		return setter.WithPage(pageable.PageNumber() + 1), true
+	}
+	next := Paging{
+		Page:  pageable.PageNumber() + 1,
+		Size:  pageable.PageSize(),
+		Sorts: pageable.PageSorts(),
+	}
+	if policer, ok := requested.(sizePolicer); ok {
+		next.SizePolicy = policer.PageSizePolicy()
+	}
+	return next, true
+}
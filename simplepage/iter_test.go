@@ -0,0 +1,72 @@
+package simplepage
+
+import (
+	"context"
+	"errors"
+	"testing"
+)
+
+func TestItems(t *testing.T) {
+	all := make([]int, 0, 25)
+	for i := range 25 {
+		all = append(all, i)
+	}
+	fetched := 0
+	fetch := func(_ context.Context, pageable Pageable) (Paged[int], error) {
+		fetched++
+		return PageSlice(pageable, all), nil
+	}
+
+	for _, options := range [][]IterOption{nil, {WithPrefetch()}} {
+		fetched = 0
+		items := make([]int, 0, len(all))
+		for item, err := range Items(context.Background(), NewPaging(1, 10), fetch, options...) {
+			if err != nil {
+				t.Fatal(err)
+			}
+			items = append(items, item)
+		}
+		if len(items) != 25 || items[24] != 24 || fetched != 3 {
+			t.Fatalf("unexpected items %v (fetched %d pages)", items, fetched)
+		}
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	var err error
+	for _, err = range Items(ctx, NewPaging(1, 10), fetch) {
+		cancel()
+		if err != nil {
+			break
+		}
+	}
+	if !errors.Is(err, context.Canceled) {
+		t.Fatalf("expected context canceled, got %v", err)
+	}
+}
+
+func TestPageSeq(t *testing.T) {
+	all := make([]int, 25)
+	sizes := make([]int, 0, 3)
+	fetch := func(_ context.Context, pageable Pageable) (Paged[int], error) {
+		sizes = append(sizes, pageable.PageSize())
+		if pageable.PageNumber() == 3 {
+			return nil, errors.New("boom")
+		}
+		return PageSlice(pageable, all), nil
+	}
+
+	// Page 1 is requested with size 7, which the policy corrects to 10.
+	paging := Paging{Page: 1, Size: 7, SizePolicy: &SizePolicy{Allowed: []int{10, 20}}}
+	seq, errFn := PageSeq(context.Background(), paging, fetch)
+	pages := 0
+	for range seq {
+		pages++
+	}
+	if pages != 2 || errFn() == nil || errFn().Error() != "boom" {
+		t.Fatalf("unexpected pages %d, err %v", pages, errFn())
+	}
+	if len(sizes) != 3 || sizes[1] != 10 || sizes[2] != 10 {
+		t.Fatalf("size policy must be kept, got sizes %v", sizes)
+	}
+}
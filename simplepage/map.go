@@ -0,0 +1,101 @@
+package simplepage
+
+import "context"
+
+// Map convert the items of the page using fn, preserving the kind and metadata of the page.
+//
+// [Slice] and [Page] (and pointers to them) are converted to the same kind.
+// Other [Paged] implementations, including types embedding [Page] such as page.Page,
+// are converted to [Page] if they implement [Totaler], otherwise to [Slice].
+// Use page.Map to also keep the URL state of page.Page.
+func Map[T, U any](p Paged[T], fn func(item T) U) Paged[U] {
+	items := make([]U, 0, len(p.GetItems()))
+	for _, item := range p.GetItems() {
+		items = append(items, fn(item))
+	}
+	return WithItems(p, items)
+}
+
+// MapErr is [Map] with fn that can fail.
+// The conversion stops at the first error.
+func MapErr[T, U any](p Paged[T], fn func(item T) (U, error)) (Paged[U], error) {
+	return MapContext(context.Background(), p, func(_ context.Context, item T) (U, error) {
+		return fn(item)
+	})
+}
+
+// MapContext is [MapErr] with context, which stops the conversion when the context is done.
+func MapContext[T, U any](ctx context.Context, p Paged[T], fn func(ctx context.Context, item T) (U, error)) (Paged[U], error) {
+	items := make([]U, 0, len(p.GetItems()))
+	for _, item := range p.GetItems() {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+		u, err := fn(ctx, item)
+		if err != nil {
+			return nil, err
+		}
+		items = append(items, u)
+	}
+	return WithItems(p, items), nil
+}
+
+// WithItems return a page of the same kind and metadata as p, with items replaced.
+// See [Map] for how the kind is preserved.
+func WithItems[T, U any](p Paged[T], items []U) Paged[U] {
+	if p.GetItems() == nil && len(items) == 0 {
+		items = nil
+	}
+	switch v := any(p).(type) {
+	case Slice[T]:
+		return sliceWithItems(v, items)
+	case *Slice[T]:
+		s := sliceWithItems(*v, items)
+		return &s
+	case Page[T]:
+		return pageWithItems(v, items)
+	case *Page[T]:
+		page := pageWithItems(*v, items)
+		return &page
+	}
+
+	hasNext := false
+	if next, ok := p.(hasNexter); ok {
+		hasNext = next.HasNext()
+	}
+	slice := NewSlice(p.GetPageable(), items, hasNext)
+	totaler, ok := p.(Totaler)
+	if !ok {
+		return slice
+	}
+	page := Page[U]{
+		Slice:      slice,
+		TotalPages: totaler.GetTotalPages(),
+		TotalItems: totaler.GetTotalItems(),
+	}
+	if capped, ok := p.(TotalCapper); ok {
+		page.IsTotalCapped = capped.TotalCapped()
+	}
+	return page
+}
+
+func sliceWithItems[T, U any](s Slice[T], items []U) Slice[U] {
+	return Slice[U]{
+		Items:      items,
+		HasNext:    s.HasNext,
+		HasPrev:    s.HasPrev,
+		PageNumber: s.PageNumber,
+		PageSize:   s.PageSize,
+		Sorts:      s.Sorts,
+		IsUnpaged:  s.IsUnpaged,
+	}
+}
+
+func pageWithItems[T, U any](p Page[T], items []U) Page[U] {
+	return Page[U]{
+		Slice:         sliceWithItems(p.Slice, items),
+		TotalPages:    p.TotalPages,
+		TotalItems:    p.TotalItems,
+		IsTotalCapped: p.IsTotalCapped,
+	}
+}
@@ -0,0 +1,189 @@
+package simplepage
+
+import (
+	"cmp"
+	"fmt"
+	"reflect"
+	"slices"
+	"time"
+)
+
+// SliceOption is the option for [PageSlice].
+type SliceOption[T any] func(*sliceOptions[T])
+
+type sliceOptions[T any] struct {
+	accessors map[string]func(item T) any
+	filters   []func(item T) bool
+}
+
+// WithSortAccessor register the accessor of the sort field, used instead of reflection.
+func WithSortAccessor[T any](field string, accessor func(item T) any) SliceOption[T] {
+	return func(options *sliceOptions[T]) {
+		if options.accessors == nil {
+			options.accessors = make(map[string]func(item T) any)
+		}
+		options.accessors[field] = accessor
+	}
+}
+
+// WithFilter only keep items that match the filter.
+// Multiple filters can be registered, and items must match all of them.
+func WithFilter[T any](filter func(item T) bool) SliceOption[T] {
+	return func(options *sliceOptions[T]) {
+		options.filters = append(options.filters, filter)
+	}
+}
+
+// PageSlice create a [Page] from all items in memory.
+// The items are filtered, sorted by [Pageable.PageSorts], then sliced by the page offset and size.
+// The given slice is not modified.
+//
+// Sort field values are read using the accessor registered by [WithSortAccessor],
+// or by reflection from struct fields (by name, json tag or case-insensitive name) and map keys.
+// Field that cannot be read is considered equal for all items.
+func PageSlice[T any](pageable Pageable, all []T, options ...SliceOption[T]) Page[T] {
+	opt := sliceOptions[T]{}
+	for _, option := range options {
+		option(&opt)
+	}
+
+	items := make([]T, 0, len(all))
+	for _, item := range all {
+		if matchAll(item, opt.filters) {
+			items = append(items, item)
+		}
+	}
+
+	if sorts := pageable.PageSorts(); len(sorts) > 0 {
+		accessors := make([]func(item T) any, 0, len(sorts))
+		for _, sort := range sorts {
+			accessors = append(accessors, opt.accessor(sort.Field))
+		}
+		slices.SortStableFunc(items, func(a, b T) int {
+			for i, sort := range sorts {
+				c := compareValues(accessors[i](a), accessors[i](b))
+				if c == 0 {
+					continue
+				}
+				if sort.IsDesc {
+					return -c
+				}
+				return c
+			}
+			return 0
+		})
+	}
+
+	total := int64(len(items))
+	if pageable.IsUnpaged() {
+		return NewPage(pageable, items, total)
+	}
+	offset := min(pageable.PageOffset(), total)
+	end := min(offset+int64(pageable.PageSize()), total)
+	return NewPage(pageable, items[offset:end], total)
+}
+
+func (o sliceOptions[T]) accessor(field string) func(item T) any {
+	if accessor, ok := o.accessors[field]; ok {
+		return accessor
+	}
+	return func(item T) any {
+		v, _ := fieldValue(item, field)
+		return v
+	}
+}
+
+func matchAll[T any](item T, filters []func(item T) bool) bool {
+	for _, filter := range filters {
+		if !filter(item) {
+			return false
+		}
+	}
+	return true
+}
+
+// compareValues compare two sort values.
+// Numbers, strings, booleans and [time.Time] are compared by value,
+// nil is less than any other value, and other types are compared by their formatted string.
+func compareValues(a, b any) int {
+	va, vb := indirectValue(a), indirectValue(b)
+	switch {
+	case !va.IsValid() && !vb.IsValid():
+		return 0
+	case !va.IsValid():
+		return -1
+	case !vb.IsValid():
+		return 1
+	}
+
+	if ta, ok := va.Interface().(time.Time); ok {
+		if tb, ok := vb.Interface().(time.Time); ok {
+			return ta.Compare(tb)
+		}
+	}
+
+	switch {
+	case isInt(va) && isInt(vb):
+		return cmp.Compare(va.Int(), vb.Int())
+	case isUint(va) && isUint(vb):
+		return cmp.Compare(va.Uint(), vb.Uint())
+	case isNumber(va) && isNumber(vb):
+		return cmp.Compare(toFloat(va), toFloat(vb))
+	case va.Kind() == reflect.String && vb.Kind() == reflect.String:
+		return cmp.Compare(va.String(), vb.String())
+	case va.Kind() == reflect.Bool && vb.Kind() == reflect.Bool:
+		return cmp.Compare(boolInt(va.Bool()), boolInt(vb.Bool()))
+	}
+	return cmp.Compare(fmt.Sprint(va.Interface()), fmt.Sprint(vb.Interface()))
+}
+
+func indirectValue(v any) reflect.Value {
+	rv := reflect.ValueOf(v)
+	for rv.Kind() == reflect.Pointer || rv.Kind() == reflect.Interface {
+		if rv.IsNil() {
+			return reflect.Value{}
+		}
+		rv = rv.Elem()
+	}
+	return rv
+}
+
+func isInt(v reflect.Value) bool {
+	switch v.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return true
+	default:
+		return false
+	}
+}
+
+func isUint(v reflect.Value) bool {
+	switch v.Kind() {
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return true
+	default:
+		return false
+	}
+}
+
+func isNumber(v reflect.Value) bool {
+	return isInt(v) || isUint(v) || v.Kind() == reflect.Float32 || v.Kind() == reflect.Float64
+}
+
+func toFloat(v reflect.Value) float64 {
+	switch {
+	case isInt(v):
+		return float64(v.Int())
+	case isUint(v):
+		return float64(v.Uint())
+	default:
+		return v.Float()
+	}
+}
+
+func boolInt(b bool) int {
+	if b {
+		return 1
+	}
+	return 0
+}
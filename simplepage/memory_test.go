@@ -0,0 +1,46 @@
+package simplepage
+
+import (
+	"testing"
+)
+
+func TestPageSlice(t *testing.T) {
+	type user struct {
+		Name string
+		Age  int `json:"age"`
+	}
+	all := []user{
+		{Name: "Carol", Age: 30},
+		{Name: "Alice", Age: 25},
+		{Name: "Bob", Age: 30},
+		{Name: "Dave", Age: 20},
+		{Name: "Eve", Age: 35},
+	}
+
+	p := PageSlice(NewPaging(1, 2, NewSorts([]string{"-age", "name"})...), all)
+	if p.TotalItems != 5 || p.TotalPages != 3 || !p.HasNext {
+		t.Fatalf("unexpected page metadata %+v", p)
+	}
+	if len(p.Items) != 2 || p.Items[0].Name != "Eve" || p.Items[1].Name != "Bob" {
+		t.Fatalf("unexpected items %v", p.Items)
+	}
+	if all[0].Name != "Carol" {
+		t.Fatalf("input slice must not be modified")
+	}
+
+	p = PageSlice(NewPaging(2, 2, NewSorts([]string{"nickname"})...), all,
+		WithFilter(func(u user) bool { return u.Age >= 25 }),
+		WithSortAccessor("nickname", func(u user) any { return u.Name[1:] }),
+	)
+	if p.TotalItems != 4 || len(p.Items) != 2 || p.Items[0].Name != "Bob" || p.Items[1].Name != "Eve" {
+		t.Fatalf("unexpected filtered page %+v", p)
+	}
+
+	p = PageSlice(NewPaging(10, 2), all)
+	if len(p.Items) != 0 || p.TotalItems != 5 {
+		t.Fatalf("out of range page must be empty %+v", p)
+	}
+	if p := PageSlice(NewUnpaged(), all); len(p.Items) != 5 {
+		t.Fatalf("unpaged must return all items")
+	}
+}
@@ -4,6 +4,9 @@
 
 var _ Paged[any] = (*Page[any])(nil)
 var _ Paged[any] = (*Slice[any])(nil)
+var _ Totaler = (*Page[any])(nil)
+var _ TotalCapper = (*Page[any])(nil)
+var _ ItemCounter = (*Slice[any])(nil)
 
 // Paged is the minimal interface for Pagination.
 // All pages results struct implement this interface.
@@ -17,6 +20,27 @@ type Paged[T any] interface {
 	IsEmpty() bool
 }
 
+// Totaler is implemented by pages that know the total items count, such as [Page].
+type Totaler interface {
+	GetTotalItems() int64
+	GetTotalPages() int
+}
+
+// TotalCapper is implemented by pages whose total may be capped, such as [Page].
+type TotalCapper interface {
+	TotalCapped() bool
+}
+
+// ItemCounter is implemented by pages that know the number of their items, such as [Slice].
+type ItemCounter interface {
+	ItemCount() int
+}
+
+// hasNexter is implemented by pages that know whether there is a next page, such as page.Page.
+type hasNexter interface {
+	HasNext() bool
+}
+
 // Slice is the basic paginated data without total items count.
 type Slice[T any] struct {
 	Items   []T  `json:"items"`
@@ -41,6 +65,11 @@ func (p Slice[T]) IsEmpty() bool {
 	return len(p.Items) == 0
 }
 
+// ItemCount return the number of items in this Slice.
+func (p Slice[T]) ItemCount() int {
+	return len(p.Items)
+}
+
 // GetPageable reconstruct [Paging] from this Slice data,
 // used for further processing Items and constructing new Slice.
 func (p Slice[T]) GetPageable() Pageable {
@@ -84,6 +113,23 @@ type Page[T any] struct {
 	Slice[T]
 	TotalPages int   `json:"totalPages"`
 	TotalItems int64 `json:"totalItems"`
+	// IsTotalCapped whether the TotalItems is capped, meaning that there are more items than TotalItems.
+	IsTotalCapped bool `json:"isTotalCapped,omitempty"`
+}
+
+// GetTotalItems return the total items count.
+func (p Page[T]) GetTotalItems() int64 {
+	return p.TotalItems
+}
+
+// GetTotalPages return the total pages count.
+func (p Page[T]) GetTotalPages() int {
+	return p.TotalPages
+}
+
+// TotalCapped return [Page.IsTotalCapped].
+func (p Page[T]) TotalCapped() bool {
+	return p.IsTotalCapped
 }
 
 // NewPage create new [Page].
@@ -0,0 +1,204 @@
+package simplepage
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+)
+
+var (
+	// ErrSortNotAllowed is returned when the sort field is not allowed by the [SortPolicy].
+	ErrSortNotAllowed = errors.New("sort field not allowed")
+	// ErrTooManySorts is returned when there are more sorts than allowed by the [SortPolicy].
+	ErrTooManySorts = errors.New("too many sorts")
+)
+
+// SortError is returned when a sort is rejected by a strict [SortPolicy].
+type SortError struct {
+	Field string
+	Err   error
+}
+
+func (e *SortError) Error() string {
+	return fmt.Sprintf("sort %q: %s", e.Field, e.Err.Error())
+}
+
+func (e *SortError) Unwrap() error {
+	return e.Err
+}
+
+// SortPolicy restrict which [Sorts] can be requested.
+type SortPolicy struct {
+	// Fields maps allowed (public) sort fields to column expressions.
+	// An empty column expression means the field name is used as the column.
+	Fields map[string]string
+	// Default sorts used when there is no valid sort.
+	Default Sorts
+	// MaxSorts the maximum number of sort keys.
+	// Zero means unlimited.
+	MaxSorts int
+	// Strict whether invalid sorts are rejected with [SortError] instead of silently dropped.
+	Strict bool
+}
+
+// Allows return whether the field is allowed by this policy.
+func (p SortPolicy) Allows(field string) bool {
+	_, ok := p.Fields[field]
+	return ok
+}
+
+// Column return the column expression of the given field,
+// or false if the field is not allowed.
+func (p SortPolicy) Column(field string) (string, bool) {
+	column, ok := p.Fields[field]
+	if !ok {
+		return "", false
+	}
+	if column == "" {
+		return field, true
+	}
+	return column, true
+}
+
+// Apply return the sorts that are allowed by this policy.
+// Not allowed and duplicated fields are dropped, and the remaining sorts are limited to [SortPolicy.MaxSorts].
+// If there is no remaining sort, [SortPolicy.Default] is returned.
+//
+// If the policy is strict, a [SortError] is returned for the first invalid sort.
+// The returned sorts are always valid even if an error is returned.
+func (p SortPolicy) Apply(sorts Sorts) (Sorts, error) {
+	var err error
+	allowed := make(Sorts, 0, len(sorts))
+	seen := make(map[string]struct{}, len(sorts))
+	for _, sort := range sorts {
+		if _, ok := seen[sort.Field]; ok {
+			continue
+		}
+		if !p.Allows(sort.Field) {
+			if p.Strict && err == nil {
+				err = &SortError{Field: sort.Field, Err: ErrSortNotAllowed}
+			}
+			continue
+		}
+		if p.MaxSorts > 0 && len(allowed) >= p.MaxSorts {
+			if p.Strict && err == nil {
+				err = &SortError{Field: sort.Field, Err: ErrTooManySorts}
+			}
+			continue
+		}
+		seen[sort.Field] = struct{}{}
+		allowed = append(allowed, sort)
+	}
+	if len(allowed) == 0 {
+		return p.Default, err
+	}
+	return allowed, err
+}
+
+var (
+	// ErrPageSizeNotAllowed is returned when the page size is not allowed by the [SizePolicy].
+	ErrPageSizeNotAllowed = errors.New("page size not allowed")
+	// ErrInvalidPageNumber is returned when the page number is not positive.
+	ErrInvalidPageNumber = errors.New("invalid page number")
+)
+
+// SizeError is returned when a page size is rejected by [SizePolicy.Validate].
+type SizeError struct {
+	Size int
+	Err  error
+}
+
+func (e *SizeError) Error() string {
+	return fmt.Sprintf("page size %d: %s", e.Size, e.Err.Error())
+}
+
+func (e *SizeError) Unwrap() error {
+	return e.Err
+}
+
+// SizePolicy restrict the page size that can be requested.
+// All fields are optional.
+type SizePolicy struct {
+	// Default the page size used when no size is requested.
+	// Zero means [DefaultPageSize].
+	Default int
+	// Max the maximum page size.
+	// Zero means [MaxPageSize].
+	Max int
+	// Allowed the list of allowed page sizes.
+	// Empty means any size up to [SizePolicy.Max] is allowed.
+	Allowed []int
+}
+
+// Size return the page size to be used for the requested size.
+// Non-positive size is replaced by the default size, size larger than max is clamped,
+// and size that is not allowed is replaced by the closest allowed size (prefer the smaller one on tie).
+// The default size is also clamped and replaced, so it is always a valid size.
+func (p SizePolicy) Size(size int) int {
+	if size <= 0 {
+		size = p.Default
+		if size <= 0 {
+			size = DefaultPageSize
+		}
+	}
+	size = min(size, p.max())
+	if len(p.Allowed) == 0 {
+		return size
+	}
+
+	closest := p.Allowed[0]
+	for _, allowed := range p.Allowed[1:] {
+		d, c := abs(allowed-size), abs(closest-size)
+		if d < c || (d == c && allowed < closest) {
+			closest = allowed
+		}
+	}
+	return closest
+}
+
+// Sizes return the page sizes to choose from, for rendering a size selector.
+// Returns the allowed sizes if any, or the default size and its double and quadruple up to the max size.
+func (p SizePolicy) Sizes() []int {
+	if len(p.Allowed) > 0 {
+		return slices.Clone(p.Allowed)
+	}
+	size := p.Size(0)
+	sizes := make([]int, 0, 3)
+	for _, n := range []int{size, size * 2, size * 4} {
+		if n <= p.max() {
+			sizes = append(sizes, n)
+		}
+	}
+	return sizes
+}
+
+// Validate return a [SizeError] if the requested size is not allowed,
+// instead of silently correcting it like [SizePolicy.Size].
+func (p SizePolicy) Validate(size int) error {
+	if size <= 0 || size > p.max() {
+		return &SizeError{Size: size, Err: ErrPageSizeNotAllowed}
+	}
+	if len(p.Allowed) == 0 {
+		return nil
+	}
+	for _, allowed := range p.Allowed {
+		if size == allowed {
+			return nil
+		}
+	}
+	return &SizeError{Size: size, Err: ErrPageSizeNotAllowed}
+}
+
+func (p SizePolicy) max() int {
+	if p.Max > 0 {
+		return p.Max
+	}
+	return MaxPageSize
+}
+
+func abs(n int) int {
+	if n < 0 {
+		return -n
+	}
+	return n
+}
@@ -0,0 +1,99 @@
+package simplepage
+
+import (
+	"errors"
+	"slices"
+	"strings"
+	"testing"
+)
+
+func TestSortPolicyApply(t *testing.T) {
+	policy := SortPolicy{
+		Fields:   map[string]string{"name": "u.name", "created": "", "id": ""},
+		Default:  NewSorts([]string{"-created"}),
+		MaxSorts: 2,
+	}
+	tests := []struct {
+		name    string
+		sorts   []string
+		strict  bool
+		want    string
+		wantErr error
+	}{
+		{"allowed", []string{"name", "-id"}, false, "name,-id", nil},
+		{"dedupe", []string{"name", "-name", "id"}, false, "name,id", nil},
+		{"max sorts", []string{"name", "id", "created"}, false, "name,id", nil},
+		{"max sorts strict", []string{"name", "id", "created"}, true, "name,id", ErrTooManySorts},
+		{"not allowed", []string{"password", "id"}, false, "id", nil},
+		{"not allowed strict", []string{"password", "id"}, true, "id", ErrSortNotAllowed},
+		{"default when empty", nil, false, "-created", nil},
+		{"default when none allowed", []string{"password"}, false, "-created", nil},
+	}
+	for _, test := range tests {
+		p := policy
+		p.Strict = test.strict
+		sorts, err := p.Apply(NewSorts(test.sorts))
+		got := make([]string, 0, len(sorts))
+		for _, sort := range sorts {
+			got = append(got, sort.String())
+		}
+		if strings.Join(got, ",") != test.want {
+			t.Fatalf("%s: sorts = %v, want %s", test.name, got, test.want)
+		}
+		if !errors.Is(err, test.wantErr) {
+			t.Fatalf("%s: err = %v, want %v", test.name, err, test.wantErr)
+		}
+		var sortErr *SortError
+		if test.wantErr != nil && !errors.As(err, &sortErr) {
+			t.Fatalf("%s: err must be SortError, got %T", test.name, err)
+		}
+	}
+
+	if column, ok := policy.Column("name"); !ok || column != "u.name" {
+		t.Fatalf("column = %q, %v", column, ok)
+	}
+	if column, ok := policy.Column("id"); !ok || column != "id" {
+		t.Fatalf("column = %q, %v", column, ok)
+	}
+	if _, ok := policy.Column("password"); ok {
+		t.Fatalf("password must not be allowed")
+	}
+}
+
+func TestSizePolicySizes(t *testing.T) {
+	tests := []struct {
+		policy SizePolicy
+		want   []int
+	}{
+		{SizePolicy{}, []int{DefaultPageSize, DefaultPageSize * 2, DefaultPageSize * 4}},
+		{SizePolicy{Default: 10, Max: 30}, []int{10, 20}},
+		{SizePolicy{Default: 50, Max: 30}, []int{30}},
+		{SizePolicy{Default: 10, Allowed: []int{5, 25}}, []int{5, 25}},
+	}
+	for _, test := range tests {
+		if got := test.policy.Sizes(); !slices.Equal(got, test.want) {
+			t.Fatalf("sizes of %+v = %v, want %v", test.policy, got, test.want)
+		}
+	}
+}
+
+func TestSizePolicySize(t *testing.T) {
+	tests := []struct {
+		policy SizePolicy
+		size   int
+		want   int
+	}{
+		{SizePolicy{}, 0, DefaultPageSize},
+		{SizePolicy{}, 1000, MaxPageSize},
+		{SizePolicy{Default: 10}, -1, 10},
+		{SizePolicy{Default: 50, Max: 30}, 0, 30},
+		{SizePolicy{Default: 15, Allowed: []int{10, 20}}, 0, 10},
+		{SizePolicy{Allowed: []int{10, 50}}, 0, 10},
+		{SizePolicy{Allowed: []int{10, 50}}, 40, 50},
+	}
+	for _, test := range tests {
+		if got := test.policy.Size(test.size); got != test.want {
+			t.Fatalf("size %d of %+v = %d, want %d", test.size, test.policy, got, test.want)
+		}
+	}
+}
@@ -10,6 +10,7 @@
 )
 
 var _ Pageable = (*Paging)(nil)
+var _ PageSetter = (*Paging)(nil)
 
 // Pageable interface for requesting/constructing a page.
 type Pageable interface {
@@ -41,7 +42,8 @@ type Paging struct {
 	Unpaged bool `json:"-" form:"-"`
 	// Deprecated: write-only, for read use [Paging.PageNumber].
 	Page int `json:"page" form:"page"`
-	// When integrating with gin, it can be controlled by registering a "pagesize" validator.
+	// When integrating with gin, it can be controlled by registering a "pagesize" validator,
+	// which can be implemented using [SizePolicy.Validate].
 	// For other frameworks, you may need to check by hand or write your own integration.
 	// Deprecated: write-only, for read use [Paging.PageSize].
 	Size int `json:"pageSize" form:"pageSize" binding:"pagesize"`
@@ -51,6 +53,8 @@ type Paging struct {
 	// It is recommended to validate the sort values before using them.
 	// Deprecated: write-only, for read use [SortablePaging.PageSorts].
 	Sorts Sorts `json:"sorts" form:"sorts"`
+	// SizePolicy overrides the default and maximum page size used by [Paging.PageSize].
+	SizePolicy *SizePolicy `json:"-" form:"-"`
 }
 
 // IsUnpaged return whether paging is disabled.
@@ -67,7 +71,12 @@ func (p Paging) PageNumber() int {
 
 // PageSize returning the page size.
 // Never <= 0.
+//
+// If [Paging.SizePolicy] is set, the size is corrected by [SizePolicy.Size].
 func (p Paging) PageSize() int {
+	if p.SizePolicy != nil {
+		return p.SizePolicy.Size(p.Size)
+	}
 	if p.Size > 0 {
 		return min(p.Size, MaxPageSize)
 	}
@@ -84,12 +93,24 @@ func (p Paging) PageSorts() Sorts {
 	return p.Sorts
 }
 
+// PageSizePolicy return the [Paging.SizePolicy], or nil if the default limits are used.
+func (p Paging) PageSizePolicy() *SizePolicy {
+	return p.SizePolicy
+}
+
+// WithPage return a copy of this paging with the page number replaced, see [PageSetter].
+func (p Paging) WithPage(page int) Pageable {
+	p.Page = page
+	return p
+}
+
 // Unsorted return a [Paging] without sorting.
 func (p Paging) Unsorted() Paging {
 	return Paging{
-		Unpaged: p.Unpaged,
-		Page:    p.Page,
-		Size:    p.Size,
+		Unpaged:    p.Unpaged,
+		Page:       p.Page,
+		Size:       p.Size,
+		SizePolicy: p.SizePolicy,
 	}
 }
 
@@ -27,6 +27,15 @@ func (s Sort) FieldStrict() string {
 	return s.Field
 }
 
+// String return the sort in the format accepted by [NewSorts],
+// which is the field prefixed by a negative sign '-' for desc sort.
+func (s Sort) String() string {
+	if s.IsDesc {
+		return "-" + s.Field
+	}
+	return s.Field
+}
+
 // NewSorts create a list of [Sort] from a list of string.
 // String starting with a negative sign '-' indicates desc sort.
 // Optionally, starting with a positive sign '+' indicates asc sort.
@@ -69,7 +78,8 @@ func (s *Sorts) UnmarshalParam(param string) error {
 	return nil
 }
 
-// UnmarshalJSON support coma separated list string or array of string.
+// UnmarshalJSON support coma separated list string, array of string,
+// or array of [Sort] object (which is how [Sorts] is marshaled).
 func (s *Sorts) UnmarshalJSON(b []byte) error {
 	data := string(b)
 	if data == "null" {
@@ -81,11 +91,26 @@ func (s *Sorts) UnmarshalJSON(b []byte) error {
 		return s.UnmarshalParam(data)
 	}
 
-	var sorts []string
-	if err := json.Unmarshal(b, &sorts); err != nil {
+	var raws []json.RawMessage
+	if err := json.Unmarshal(b, &raws); err != nil {
 		return err
 	}
-	*s = NewSorts(sorts)
+	sorts := make(Sorts, 0, len(raws))
+	for _, raw := range raws {
+		var str string
+		if err := json.Unmarshal(raw, &str); err == nil {
+			sorts = append(sorts, NewSorts([]string{str})...)
+			continue
+		}
+		var sort Sort
+		if err := json.Unmarshal(raw, &sort); err != nil {
+			return err
+		}
+		if sort.Field != "" {
+			sorts = append(sorts, sort)
+		}
+	}
+	*s = sorts
 	return nil
 }
 
@@ -0,0 +1,231 @@
+// Package sqlpage build SQL pagination clauses from [simplepage.Pageable].
+//
+// All column expressions come from the [simplepage.SortPolicy], so sort fields
+// requested by users are never written into the query as is.
+package sqlpage
+
+import (
+	"github.com/mawngo/go-tmpls/v2/simplepage"
+	"strconv"
+	"strings"
+)
+
+// Dialect is the placeholder style of the database.
+type Dialect int
+
+const (
+	// Postgres uses numbered placeholders: $1, $2, ...
+	Postgres Dialect = iota
+	// MySQL uses question mark placeholders.
+	MySQL
+	// SQLite uses question mark placeholders.
+	SQLite
+)
+
+// Placeholder return the bind placeholder of the n-th (1-based) argument.
+func (d Dialect) Placeholder(n int) string {
+	if d == Postgres {
+		return "$" + strconv.Itoa(n)
+	}
+	return "?"
+}
+
+// Builder build pagination clauses for a [Dialect],
+// with sorts restricted by a [simplepage.SortPolicy].
+type Builder struct {
+	dialect Dialect
+	policy  simplepage.SortPolicy
+}
+
+// New create a new [Builder].
+func New(dialect Dialect, policy simplepage.SortPolicy) Builder {
+	return Builder{
+		dialect: dialect,
+		policy:  policy,
+	}
+}
+
+// OrderBy return the ORDER BY clause of the sorts.
+// Sorts are filtered by the policy, see [simplepage.SortPolicy.Apply].
+// Return an empty string if there is no sort.
+func (b Builder) OrderBy(sorts simplepage.Sorts) string {
+	// Apply always return valid sorts, the error only matter when binding the request.
+	sorts, _ = b.policy.Apply(sorts)
+	if len(sorts) == 0 {
+		return ""
+	}
+	var sb strings.Builder
+	for _, sort := range sorts {
+		column, ok := b.policy.Column(sort.Field)
+		if !ok {
+			continue
+		}
+		if sb.Len() == 0 {
+			sb.WriteString("ORDER BY ")
+		} else {
+			sb.WriteString(", ")
+		}
+		sb.WriteString(column)
+		if sort.IsDesc {
+			sb.WriteString(" DESC")
+		} else {
+			sb.WriteString(" ASC")
+		}
+	}
+	return sb.String()
+}
+
+// Limit return the LIMIT clause of the pageable, used for keyset pagination.
+// Return an empty string if the pageable is unpaged.
+func (b Builder) Limit(p simplepage.Pageable) string {
+	if p.IsUnpaged() {
+		return ""
+	}
+	return "LIMIT " + strconv.Itoa(p.PageSize())
+}
+
+// LimitOffset return the LIMIT and OFFSET clause of the pageable.
+// Return an empty string if the pageable is unpaged.
+func (b Builder) LimitOffset(p simplepage.Pageable) string {
+	if p.IsUnpaged() {
+		return ""
+	}
+	return b.Limit(p) + " OFFSET " + strconv.FormatInt(p.PageOffset(), 10)
+}
+
+// Paginate return the ORDER BY, LIMIT and OFFSET clauses of the pageable.
+func (b Builder) Paginate(p simplepage.Pageable) string {
+	return join(b.OrderBy(p.PageSorts()), b.LimitOffset(p))
+}
+
+// Keyset return the condition selecting the items after (or before) the cursor, without the WHERE keyword,
+// and args with the cursor values appended.
+// Placeholders are numbered after the existing args.
+//
+// The cursor sorts are resolved like [Builder.OrderBy]: duplicated fields are dropped and the sorts are limited
+// to [simplepage.SortPolicy.MaxSorts], so the condition matches the order of [Builder.OrderBy]
+// of [simplepage.Cursor.QuerySorts].
+// A [simplepage.SortError] is returned if the cursor contains a field that is not allowed by the policy.
+// NULL values are not supported.
+func (b Builder) Keyset(cursor simplepage.Cursor, args []any) (string, []any, error) {
+	cursor, err := b.resolve(cursor)
+	if err != nil {
+		return "", args, err
+	}
+	keys := cursor.Keys()
+	if len(keys) == 0 {
+		return "", args, nil
+	}
+	columns := make([]string, 0, len(keys))
+	for _, key := range keys {
+		// Resolved sorts are always allowed.
+		column, _ := b.policy.Column(key.Field)
+		columns = append(columns, column)
+	}
+
+	// Postgres placeholders can be reused, so each value is only bound once.
+	placeholders := make([]string, len(keys))
+	bind := func(i int) string {
+		if b.dialect != Postgres {
+			args = append(args, keys[i].Value)
+			return b.dialect.Placeholder(len(args))
+		}
+		if placeholders[i] == "" {
+			args = append(args, keys[i].Value)
+			placeholders[i] = b.dialect.Placeholder(len(args))
+		}
+		return placeholders[i]
+	}
+
+	// Expanded form of the row comparison, which support mixed directions:
+	// (a > ?) OR (a = ? AND b > ?) OR ...
+	var sb strings.Builder
+	sb.WriteString("(")
+	for i, key := range keys {
+		if i > 0 {
+			sb.WriteString(" OR ")
+		}
+		sb.WriteString("(")
+		for j := 0; j < i; j++ {
+			sb.WriteString(columns[j])
+			sb.WriteString(" = ")
+			sb.WriteString(bind(j))
+			sb.WriteString(" AND ")
+		}
+		sb.WriteString(columns[i])
+		if key.IsGreater {
+			sb.WriteString(" > ")
+		} else {
+			sb.WriteString(" < ")
+		}
+		sb.WriteString(bind(i))
+		sb.WriteString(")")
+	}
+	sb.WriteString(")")
+	return sb.String(), args, nil
+}
+
+// Seek return the keyset condition, without the WHERE keyword, and the ORDER BY and LIMIT clauses
+// for querying the items after (or before) the cursor.
+// If the cursor is empty (the first page), the items are ordered by the sorts of the pageable.
+// See [Builder.Keyset].
+func (b Builder) Seek(p simplepage.Pageable, cursor simplepage.Cursor, args []any) (string, string, []any, error) {
+	cursor, err := b.resolve(cursor)
+	if err != nil {
+		return "", "", args, err
+	}
+	where, args, err := b.Keyset(cursor, args)
+	if err != nil {
+		return "", "", args, err
+	}
+	sorts := cursor.QuerySorts()
+	if len(cursor.Sorts) == 0 {
+		// The first page has no cursor, so it is ordered by the requested sorts like the next pages.
+		sorts = p.PageSorts()
+	}
+	return where, join(b.OrderBy(sorts), b.Limit(p)), args, nil
+}
+
+// resolve return the cursor with its sorts resolved by the policy, and the values of the remaining sorts.
+// An empty cursor is returned as is, as there is no boundary item to compare with.
+func (b Builder) resolve(cursor simplepage.Cursor) (simplepage.Cursor, error) {
+	if len(cursor.Sorts) == 0 {
+		return cursor, nil
+	}
+	for _, sort := range cursor.Sorts {
+		if !b.policy.Allows(sort.Field) {
+			return cursor, &simplepage.SortError{Field: sort.Field, Err: simplepage.ErrSortNotAllowed}
+		}
+	}
+	// Too many sorts are truncated like OrderBy does, instead of rejected.
+	policy := b.policy
+	policy.Strict = false
+	sorts, _ := policy.Apply(cursor.Sorts)
+
+	values := make([]any, 0, len(sorts))
+	for _, sort := range sorts {
+		// Apply keep the first occurrence of duplicated fields.
+		for i, s := range cursor.Sorts {
+			if s.Field != sort.Field {
+				continue
+			}
+			var value any
+			if i < len(cursor.Values) {
+				value = cursor.Values[i]
+			}
+			values = append(values, value)
+			break
+		}
+	}
+	return simplepage.Cursor{Sorts: sorts, Values: values, IsPrevious: cursor.IsPrevious}, nil
+}
+
+func join(clauses ...string) string {
+	res := make([]string, 0, len(clauses))
+	for _, clause := range clauses {
+		if clause != "" {
+			res = append(res, clause)
+		}
+	}
+	return strings.Join(res, " ")
+}
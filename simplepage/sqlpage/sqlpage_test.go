@@ -0,0 +1,101 @@
+package sqlpage
+
+import (
+	"github.com/mawngo/go-tmpls/v2/simplepage"
+	"testing"
+)
+
+var policy = simplepage.SortPolicy{
+	Fields: map[string]string{
+		"name":    "u.name",
+		"created": "u.created_at",
+		"id":      "",
+	},
+}
+
+func TestPaginate(t *testing.T) {
+	p := simplepage.NewPaging(3, 10, simplepage.NewSorts([]string{"-created", "password", "name"})...)
+	if got := New(Postgres, policy).Paginate(p); got != "ORDER BY u.created_at DESC, u.name ASC LIMIT 10 OFFSET 20" {
+		t.Fatalf("unexpected clause %q", got)
+	}
+	if got := New(MySQL, policy).Paginate(simplepage.NewUnpaged()); got != "" {
+		t.Fatalf("unpaged must not have clause, got %q", got)
+	}
+}
+
+func TestKeyset(t *testing.T) {
+	cursor := simplepage.Cursor{
+		Sorts:  simplepage.NewSorts([]string{"-created", "id"}),
+		Values: []any{"2024-01-01", 10},
+	}
+
+	where, args, err := New(Postgres, policy).Keyset(cursor, []any{"active"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if where != "((u.created_at < $2) OR (u.created_at = $2 AND id > $3))" {
+		t.Fatalf("unexpected condition %q", where)
+	}
+	if len(args) != 3 || args[1] != "2024-01-01" || args[2] != 10 {
+		t.Fatalf("unexpected args %v", args)
+	}
+
+	where, orderBy, args, err := New(SQLite, policy).Seek(simplepage.NewPaging(1, 5), cursor.Previous(), nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if where != "((u.created_at > ?) OR (u.created_at = ? AND id < ?))" {
+		t.Fatalf("unexpected condition %q", where)
+	}
+	if orderBy != "ORDER BY u.created_at ASC, id DESC LIMIT 5" {
+		t.Fatalf("unexpected order by %q", orderBy)
+	}
+	if len(args) != 3 {
+		t.Fatalf("unexpected args %v", args)
+	}
+
+	cursor.Sorts = simplepage.NewSorts([]string{"password"})
+	if _, _, err := New(MySQL, policy).Keyset(cursor, nil); err == nil {
+		t.Fatalf("expected error for not allowed field")
+	}
+}
+
+func TestSeekResolvedSorts(t *testing.T) {
+	p := policy
+	p.MaxSorts = 2
+	cursor := simplepage.Cursor{
+		Sorts:  simplepage.NewSorts([]string{"-created", "-created", "id", "name"}),
+		Values: []any{"2024-01-01", "2024-01-01", 10, "Bob"},
+	}
+	where, orderBy, args, err := New(Postgres, p).Seek(simplepage.NewPaging(1, 5), cursor, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if where != "((u.created_at < $1) OR (u.created_at = $1 AND id > $2))" {
+		t.Fatalf("unexpected condition %q", where)
+	}
+	if orderBy != "ORDER BY u.created_at DESC, id ASC LIMIT 5" {
+		t.Fatalf("unexpected order by %q", orderBy)
+	}
+	if len(args) != 2 || args[0] != "2024-01-01" || args[1] != 10 {
+		t.Fatalf("unexpected args %v", args)
+	}
+
+	p.Default = simplepage.NewSorts([]string{"id"})
+	first := simplepage.NewPaging(1, 5, simplepage.NewSorts([]string{"-created", "-created", "id", "name"})...)
+	where, orderBy, _, err = New(Postgres, p).Seek(first, simplepage.Cursor{}, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if where != "" || orderBy != "ORDER BY u.created_at DESC, id ASC LIMIT 5" {
+		t.Fatalf("unexpected first page clauses %q %q", where, orderBy)
+	}
+
+	_, orderBy, _, err = New(Postgres, p).Seek(simplepage.NewPaging(1, 5), simplepage.Cursor{}, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if orderBy != "ORDER BY id ASC LIMIT 5" {
+		t.Fatalf("unexpected default order by %q", orderBy)
+	}
+}
@@ -1,6 +1,7 @@
 package tmpls
 
 import (
+	"context"
 	"errors"
 	"fmt"
 	"github.com/mawngo/go-tmpls/v2/internal"
@@ -24,20 +25,37 @@
 
 var errTemplateNotFound = errors.New("template not found")
 
+// maxLocaleTemplates the maximum number of locales whose template clones are cached.
+const maxLocaleTemplates = 16
+
 // Templates collection of cached and preprocessed templates.
 type Templates struct {
 	fs             fs.FS
+	mounts         []mount
 	extensions     map[string]struct{}
 	prefixMap      map[string]string
 	separator      string
 	onExecute      OnTemplateExecuteFn
+	contextFuncs   []ContextFuncsFn
+	localeFuncs    []localeFuncs
 	preloadMatcher func(name string, path string) bool
 
 	baseFn func(name string) (Template, error)
 	// Map of parsed template by name.
 	templateMap map[string]Template
+	// Map of executed clone of parsed template by name.
+	// As html/template cannot clone an executed template, each executed template is kept twice in memory,
+	// once here and once in templateMap.
+	execMap map[string]Template
+	// Map of executed clone bound to the locale functions by locale and name,
+	// limited to maxLocaleTemplates locales.
+	localeMap map[string]map[string]Template
+	// Locales of localeMap, in insertion order.
+	localeOrder []string
 	// Map of processed template name to template paths.
 	nameMap map[string]string
+	// Map of processed template name to the file system containing it.
+	fsMap   map[string]fs.FS
 	mu      sync.RWMutex
 	nocache bool
 	nostack bool
@@ -68,15 +86,19 @@ func New(fs fs.FS, options ...TemplatesOption) (*Templates, error) {
 
 	t := &Templates{
 		fs:             fs,
+		mounts:         opt.mounts,
 		nocache:        opt.nocache,
 		nostack:        opt.nostack,
 		extensions:     opt.extensions,
 		prefixMap:      opt.prefixMap,
 		separator:      opt.pathSeparator,
 		onExecute:      opt.onExecute,
+		contextFuncs:   opt.contextFuncs,
 		preloadMatcher: opt.preloadMatcher,
 
 		templateMap:       make(map[string]Template),
+		execMap:           make(map[string]Template),
+		localeMap:         make(map[string]map[string]Template),
 		templateNameRegex: regexp.MustCompile(templateNameRegexStr),
 	}
 
@@ -99,13 +121,56 @@ func New(fs fs.FS, options ...TemplatesOption) (*Templates, error) {
 			if funcs := internal.NewBuiltinFuncMap(opt.excludeFuncs...); len(funcs) > 0 {
 				base = base.Funcs(funcs)
 			}
+			if !opt.texmode {
+				if funcs := internal.NewHTMLFuncMap(opt.excludeFuncs...); len(funcs) > 0 {
+					base = base.Funcs(funcs)
+				}
+			}
+		}
+		if opt.encodingFuncs {
+			if funcs := internal.NewEncodingFuncMap(opt.excludeFuncs...); len(funcs) > 0 {
+				base = base.Funcs(funcs)
+			}
+		}
+		if opt.safeFuncs && !opt.texmode {
+			if funcs := internal.NewSafeFuncMap(opt.excludeFuncs...); len(funcs) > 0 {
+				base = base.Funcs(funcs)
+			}
+		}
+		if len(opt.mountFuncs) > 0 {
+			base = base.Funcs(opt.mountFuncs)
 		}
 		if len(opt.funcs) > 0 {
 			base = base.Funcs(opt.funcs)
 		}
+		for _, l := range opt.localeFuncs {
+			if funcs := l.funcs(""); len(funcs) > 0 {
+				base = base.Funcs(funcs)
+			}
+		}
+		for _, fn := range opt.contextFuncs {
+			if funcs := fn(context.Background()); len(funcs) > 0 {
+				base = base.Funcs(funcs)
+			}
+		}
 		return base, nil
 	}
 
+	if !opt.disableBuiltins {
+		// Functions added by WithFuncs are not replaced by the locale-aware builtins.
+		excludes := append([]string{}, opt.excludeFuncs...)
+		for name := range opt.funcs {
+			excludes = append(excludes, name)
+		}
+		t.localeFuncs = append(t.localeFuncs, localeFuncs{
+			resolve: internal.ResolveLocale,
+			funcs: func(locale string) FuncMap {
+				return internal.NewLocaleFuncMap(locale, excludes...)
+			},
+		})
+	}
+	t.localeFuncs = append(t.localeFuncs, opt.localeFuncs...)
+
 	if err := t.scanNames(); err != nil {
 		return nil, err
 	}
@@ -114,7 +179,29 @@ func New(fs fs.FS, options ...TemplatesOption) (*Templates, error) {
 
 func (t *Templates) scanNames() error {
 	t.nameMap = make(map[string]string)
-	return fs.WalkDir(t.fs, ".", func(path string, d fs.DirEntry, err error) error {
+	t.fsMap = make(map[string]fs.FS)
+	if err := t.scanFS(t.fs, t.extensions); err != nil {
+		return err
+	}
+	for _, m := range t.mounts {
+		extensions := t.extensions
+		if len(m.extensions) > 0 {
+			extensions = make(map[string]struct{}, len(m.extensions))
+			for _, ext := range m.extensions {
+				extensions[ext] = struct{}{}
+			}
+		}
+		if err := t.scanFS(m.fs, extensions); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// scanFS add names of templates in the file system that are not already scanned from other file systems.
+func (t *Templates) scanFS(fsys fs.FS, extensions map[string]struct{}) error {
+	scanned := make(map[string]struct{})
+	return fs.WalkDir(fsys, ".", func(path string, d fs.DirEntry, err error) error {
 		if err != nil {
 			return err
 		}
@@ -125,8 +212,8 @@ func (t *Templates) scanNames() error {
 
 		path = fspath.Clean(path)
 		ext := fspath.Ext(path)
-		if len(t.extensions) > 0 {
-			if _, ok := t.extensions[ext]; !ok {
+		if len(extensions) > 0 {
+			if _, ok := extensions[ext]; !ok {
 				return nil
 			}
 		}
@@ -143,9 +230,15 @@ func (t *Templates) scanNames() error {
 		name = strings.TrimSuffix(name, ext)
 
 		if prevPath, ok := t.nameMap[name]; ok {
+			if _, ok := scanned[name]; !ok {
+				// Overridden by previous file system.
+				return nil
+			}
 			return fmt.Errorf(`template name conflict: "%s" (files %s and %s)`, name, prevPath, path)
 		}
+		scanned[name] = struct{}{}
 		t.nameMap[name] = path
+		t.fsMap[name] = fsys
 		return err
 	})
 }
@@ -180,7 +273,7 @@ func (t *Templates) resolve(c *resolveContext, name string) (Template, error) {
 		}
 	}
 
-	b, err := fs.ReadFile(t.fs, path)
+	b, err := fs.ReadFile(t.fsMap[name], path)
 	if err != nil {
 		return nil, err
 	}
@@ -323,6 +416,69 @@ func (t *Templates) LookupPath(name string) string {
 	return t.nameMap[name]
 }
 
+// resolveLocale return the resolved locale of each locale functions, and the key of the combination,
+// which is empty if all of them use the default functions.
+func (t *Templates) resolveLocale(locale string) (string, []string) {
+	if locale == "" {
+		return "", nil
+	}
+	resolved := make([]string, len(t.localeFuncs))
+	found := false
+	for i, l := range t.localeFuncs {
+		resolved[i] = l.resolve(locale)
+		found = found || resolved[i] != ""
+	}
+	if !found {
+		return "", nil
+	}
+	return strings.Join(resolved, "|"), resolved
+}
+
+// boundLocaleFuncs returns the locale functions bound to the resolved locales, see [Templates.resolveLocale].
+func (t *Templates) boundLocaleFuncs(resolved []string) FuncMap {
+	funcs := make(FuncMap)
+	for i, l := range t.localeFuncs {
+		for k, fn := range l.funcs(resolved[i]) {
+			funcs[k] = fn
+		}
+	}
+	return funcs
+}
+
+// lookupLocale returns the executed clone of the template bound to the resolved locales.
+// Clones are cached for up to maxLocaleTemplates keys, evicting the oldest key when exceeded.
+func (t *Templates) lookupLocale(key string, resolved []string, name string) (Template, error) {
+	t.mu.RLock()
+	tmpl, ok := t.localeMap[key][name]
+	t.mu.RUnlock()
+	if ok {
+		return tmpl, nil
+	}
+
+	tmpl, err := t.Lookup(name)
+	if err != nil {
+		return nil, err
+	}
+	tmpl = tmpl.Funcs(t.boundLocaleFuncs(resolved))
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	templates, ok := t.localeMap[key]
+	if !ok {
+		if len(t.localeOrder) >= maxLocaleTemplates {
+			delete(t.localeMap, t.localeOrder[0])
+			t.localeOrder = t.localeOrder[1:]
+		}
+		templates = make(map[string]Template)
+		t.localeMap[key] = templates
+		t.localeOrder = append(t.localeOrder, key)
+	}
+	if cached, ok := templates[name]; ok {
+		return cached, nil
+	}
+	templates[name] = tmpl
+	return tmpl, nil
+}
+
 // lookup returns a template by name.
 func (t *Templates) lookup(name string) (Template, error) {
 	if t.nocache {
@@ -366,7 +522,14 @@ func (t *Templates) lookup(name string) (Template, error) {
 
 // ExecuteTemplate execute the specified template with the given data.
 func (t *Templates) ExecuteTemplate(wr io.Writer, name string, data any) error {
-	tmpl, err := t.lookup(name)
+	return t.ExecuteTemplateContext(context.Background(), wr, name, data)
+}
+
+// ExecuteTemplateContext execute the specified template with the given data,
+// binding the functions configured by [WithContextFuncs] to the context.
+// If the context has a locale (see [ContextWithLocale]), the locale-aware builtins use that locale.
+func (t *Templates) ExecuteTemplateContext(ctx context.Context, wr io.Writer, name string, data any) error {
+	tmpl, err := t.lookupExec(ctx, name)
 	if err != nil {
 		return err
 	}
@@ -378,6 +541,64 @@ func (t *Templates) ExecuteTemplate(wr io.Writer, name string, data any) error {
 	return tmpl.Execute(wr, data)
 }
 
+// lookupExec returns a template for executing, with functions bound to the context.
+// Executed templates cannot be cloned, so the cached templates are never executed.
+func (t *Templates) lookupExec(ctx context.Context, name string) (Template, error) {
+	// The locale is resolved to the supported locales, so locales sharing the same data share the clones,
+	// and locales without data use the default templates.
+	key, resolved := t.resolveLocale(LocaleFromContext(ctx))
+	var funcs FuncMap
+	for _, fn := range t.contextFuncs {
+		for k, fn := range fn(ctx) {
+			if funcs == nil {
+				funcs = make(FuncMap)
+			}
+			funcs[k] = fn
+		}
+	}
+
+	if len(funcs) > 0 || (key != "" && t.nocache) {
+		// Context functions are bound to each execution, so the template is cloned every time.
+		bound := make(FuncMap, len(funcs))
+		if key != "" {
+			bound = t.boundLocaleFuncs(resolved)
+		}
+		for k, fn := range funcs {
+			bound[k] = fn
+		}
+		tmpl, err := t.Lookup(name)
+		if err != nil {
+			return nil, err
+		}
+		return tmpl.Funcs(bound), nil
+	}
+	if key != "" {
+		return t.lookupLocale(key, resolved, name)
+	}
+
+	if t.nocache {
+		return t.lookup(name)
+	}
+	t.mu.RLock()
+	if tmpl, ok := t.execMap[name]; ok {
+		defer t.mu.RUnlock()
+		return tmpl, nil
+	}
+	t.mu.RUnlock()
+
+	tmpl, err := t.Lookup(name)
+	if err != nil {
+		return nil, err
+	}
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if cached, ok := t.execMap[name]; ok {
+		return cached, nil
+	}
+	t.execMap[name] = tmpl
+	return tmpl, nil
+}
+
 // MustExecuteTemplate execute the specified template with the given data and panic if any error occurs.
 func (t *Templates) MustExecuteTemplate(wr io.Writer, name string, data any) {
 	err := t.ExecuteTemplate(wr, name, data)
@@ -386,6 +607,15 @@ func (t *Templates) MustExecuteTemplate(wr io.Writer, name string, data any) {
 	}
 }
 
+// MustExecuteTemplateContext execute the specified template with the given data and context
+// and panic if any error occurs.
+func (t *Templates) MustExecuteTemplateContext(ctx context.Context, wr io.Writer, name string, data any) {
+	err := t.ExecuteTemplateContext(ctx, wr, name, data)
+	if err != nil {
+		panic(err)
+	}
+}
+
 // NewStandardWebFS sets up [Templates] and [http.FileServer] based on golang-standards/project-layout,
 // which read templates from web/template and serve static files from web/static.
 func NewStandardWebFS(cwd fs.FS, options ...TemplatesOption) (*Templates, http.Handler, error) {
@@ -0,0 +1,193 @@
+package tmpls
+
+import (
+	"context"
+	"strconv"
+	"strings"
+	"testing"
+	"testing/fstest"
+)
+
+func TestExecuteTemplateContextLocale(t *testing.T) {
+	fsys := fstest.MapFS{
+		"index.gohtml": {Data: []byte(`{{ numfmt . 2 }} {{ monthName 3 }}`)},
+	}
+	templates, err := New(fsys)
+	if err != nil {
+		t.Fatalf("new templates: %v", err)
+	}
+
+	render := func(ctx context.Context) string {
+		var sb strings.Builder
+		if err := templates.ExecuteTemplateContext(ctx, &sb, "index", 1234.5); err != nil {
+			t.Fatalf("execute: %v", err)
+		}
+		return sb.String()
+	}
+	if s := render(context.Background()); s != "1,234.50 March" {
+		t.Fatalf("render default = %q", s)
+	}
+	if s := render(ContextWithLocale(context.Background(), "de-DE")); s != "1.234,50 März" {
+		t.Fatalf("render de = %q", s)
+	}
+	if s := render(context.Background()); s != "1,234.50 March" {
+		t.Fatalf("render default after de = %q", s)
+	}
+	if _, err := templates.Lookup("index"); err != nil {
+		t.Fatalf("lookup after execute: %v", err)
+	}
+}
+
+func TestExecuteTemplateContextLocaleCache(t *testing.T) {
+	fsys := fstest.MapFS{
+		"index.gohtml": {Data: []byte(`{{ numfmt . 1 }}`)},
+	}
+	templates, err := New(fsys)
+	if err != nil {
+		t.Fatalf("new templates: %v", err)
+	}
+
+	render := func(locale string) {
+		var sb strings.Builder
+		ctx := ContextWithLocale(context.Background(), locale)
+		if err := templates.ExecuteTemplateContext(ctx, &sb, "index", 1.5); err != nil {
+			t.Fatalf("execute %s: %v", locale, err)
+		}
+	}
+	render("de")
+	first := templates.localeMap["de"]["index"]
+	for _, locale := range []string{"de", "de-DE", "DE", "de_AT"} {
+		render(locale)
+	}
+	if first == nil || templates.localeMap["de"]["index"] != first {
+		t.Fatalf("locale template must be cached by the resolved locale")
+	}
+	for i := range maxLocaleTemplates {
+		render("x-" + strconv.Itoa(i))
+	}
+	render("en-US")
+	if len(templates.localeMap) != 1 {
+		t.Fatalf("locales without data must not be cached: %d", len(templates.localeMap))
+	}
+
+	for i := range maxLocaleTemplates {
+		key := "x-" + strconv.Itoa(i)
+		if _, err := templates.lookupLocale(key, []string{key}, "index"); err != nil {
+			t.Fatalf("lookup: %v", err)
+		}
+	}
+	if len(templates.localeMap) != maxLocaleTemplates || len(templates.localeOrder) != maxLocaleTemplates {
+		t.Fatalf("cached locales = %d, want %d", len(templates.localeMap), maxLocaleTemplates)
+	}
+	if _, ok := templates.localeMap["de"]; ok {
+		t.Fatalf("oldest locale must be evicted")
+	}
+}
+
+func TestWithLocaleFuncs(t *testing.T) {
+	fsys := fstest.MapFS{
+		"index.gohtml": {Data: []byte(`{{ greet }} {{ numfmt 1.5 1 }}`)},
+	}
+	bound := 0
+	resolve := func(locale string) string {
+		if strings.HasPrefix(locale, "vi") {
+			return "vi"
+		}
+		return ""
+	}
+	greet := func(locale string) FuncMap {
+		bound++
+		return FuncMap{"greet": func() string {
+			if locale == "vi" {
+				return "xin chào"
+			}
+			return "hello"
+		}}
+	}
+	templates, err := New(fsys, WithLocaleFuncs(resolve, greet))
+	if err != nil {
+		t.Fatalf("new templates: %v", err)
+	}
+
+	render := func(locale string) string {
+		var sb strings.Builder
+		ctx := ContextWithLocale(context.Background(), locale)
+		if err := templates.ExecuteTemplateContext(ctx, &sb, "index", nil); err != nil {
+			t.Fatalf("execute %s: %v", locale, err)
+		}
+		return sb.String()
+	}
+	if s := render("en"); s != "hello 1.5" {
+		t.Fatalf("render en = %q", s)
+	}
+	bound = 0
+	for _, locale := range []string{"vi", "vi-VN", "vi"} {
+		if s := render(locale); s != "xin chào 1,5" {
+			t.Fatalf("render %s = %q", locale, s)
+		}
+	}
+	if s := render("de"); s != "hello 1,5" {
+		t.Fatalf("render de = %q", s)
+	}
+	if bound != 2 || len(templates.localeMap) != 2 {
+		t.Fatalf("templates must be bound once per resolved locale: %d, %d", bound, len(templates.localeMap))
+	}
+}
+
+func TestWithSafeFuncs(t *testing.T) {
+	fsys := fstest.MapFS{
+		"index.gohtml": {Data: []byte(`{{ safeHTML . }}`)},
+	}
+	templates, err := New(fsys, WithSafeFuncs())
+	if err != nil {
+		t.Fatalf("new templates: %v", err)
+	}
+	var sb strings.Builder
+	if err := templates.ExecuteTemplate(&sb, "index", "<b>x</b>"); err != nil || sb.String() != "<b>x</b>" {
+		t.Fatalf("render = %q, %v", sb.String(), err)
+	}
+
+	templates, err = New(fsys, WithSafeFuncs(), WithTextMode())
+	if err != nil {
+		t.Fatalf("new templates: %v", err)
+	}
+	if err := templates.ExecuteTemplate(&sb, "index", "x"); err == nil {
+		t.Fatalf("safe funcs must not be registered in text mode")
+	}
+}
+
+func TestWithEncodingFuncs(t *testing.T) {
+	fsys := fstest.MapFS{
+		"index.gotxt":  {Data: []byte(`{{ toJSON . }} {{ b64enc .a }}`)},
+		"index.gohtml": {Data: []byte(`<script>const d = {{ toJSON . }};</script>`)},
+	}
+	data := map[string]any{"a": "<b>"}
+	templates, err := New(fsys, WithEncodingFuncs(), WithTextMode(), WithExtensions(".gotxt"))
+	if err != nil {
+		t.Fatalf("new templates: %v", err)
+	}
+	var sb strings.Builder
+	if err := templates.ExecuteTemplate(&sb, "index", data); err != nil || sb.String() != `{"a":"<b>"} PGI+` {
+		t.Fatalf("render = %q, %v", sb.String(), err)
+	}
+
+	templates, err = New(fsys, WithEncodingFuncs(), WithSafeFuncs(), WithExtensions(".gohtml"))
+	if err != nil {
+		t.Fatalf("new templates: %v", err)
+	}
+	sb.Reset()
+	want := `<script>const d = {"a":"\u003cb\u003e"};</script>`
+	if err := templates.ExecuteTemplate(&sb, "index", data); err != nil || sb.String() != want {
+		t.Fatalf("render = %q, %v, want %q", sb.String(), err, want)
+	}
+
+	templates, err = New(fsys, WithEncodingFuncs(), WithExtensions(".gohtml"))
+	if err != nil {
+		t.Fatalf("new templates: %v", err)
+	}
+	sb.Reset()
+	want = `<script>const d = "{\"a\":\"\u003cb\u003e\"}";</script>`
+	if err := templates.ExecuteTemplate(&sb, "index", data); err != nil || sb.String() != want {
+		t.Fatalf("render without safe funcs = %q, %v, want %q", sb.String(), err, want)
+	}
+}